@@ -1,5 +1,7 @@
 module github.com/calvinfeng/go-academy
 
+go 1.22
+
 require (
 	github.com/Microsoft/go-winio v0.4.12 // indirect
 	github.com/Pallinder/go-randomdata v1.1.0
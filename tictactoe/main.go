@@ -1,10 +1,97 @@
 package main
 
-import "go-academy/tictactoe/ttt"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/calvinfeng/go-academy/tictactoe/ttt"
+)
+
+// opponents maps the values of the -ai flag to the opponent and the difficulty they stand for.
+var opponents = map[string]ttt.GameConfig{
+	"human":  {Opponent: ttt.HumanOpponent},
+	"random": {Opponent: ttt.RandomOpponent},
+	"easy":   {Opponent: ttt.ComputerOpponent, Difficulty: ttt.Easy},
+	"medium": {Opponent: ttt.ComputerOpponent, Difficulty: ttt.Medium},
+	"hard":   {Opponent: ttt.ComputerOpponent, Difficulty: ttt.Hard},
+}
+
+// usageOutput is where the usage is printed when the flags are invalid.
+var usageOutput io.Writer = os.Stderr
+
+// parseFlags parses the command line flags into the config of a game. It prints the usage and
+// returns an error if a flag is unknown or has an invalid value.
+func parseFlags(args []string) (ttt.GameConfig, error) {
+	fs := flag.NewFlagSet("tictactoe", flag.ContinueOnError)
+	fs.SetOutput(usageOutput)
+	size := fs.Int("size", 3, "size of the board, from 3 to 5")
+	ai := fs.String("ai", "hard", "opponent of player X: human, random, easy, medium or hard")
+	first := fs.String("first", "X", "mark of the player who moves first: X or O")
+
+	if err := fs.Parse(args); err != nil {
+		return ttt.GameConfig{}, err
+	}
+
+	if fs.NArg() > 0 {
+		return ttt.GameConfig{}, usageError(fs, fmt.Errorf("unexpected arguments %v", fs.Args()))
+	}
+
+	if *size < 3 || *size > 5 {
+		return ttt.GameConfig{}, usageError(fs, fmt.Errorf("-size %d must be between 3 and 5", *size))
+	}
+
+	cfg, ok := opponents[strings.ToLower(*ai)]
+	if !ok {
+		return ttt.GameConfig{}, usageError(fs, fmt.Errorf("-ai %q is not a known opponent", *ai))
+	}
+
+	cfg.Size = *size
+	cfg.First = strings.ToUpper(*first)
+	if cfg.First != "X" && cfg.First != "O" {
+		return ttt.GameConfig{}, usageError(fs, fmt.Errorf("-first %q must be X or O", *first))
+	}
+
+	return cfg, nil
+}
+
+// usageError prints err and the usage of the flags, and returns err.
+func usageError(fs *flag.FlagSet, err error) error {
+	fmt.Fprintln(fs.Output(), err)
+	fs.Usage()
+	return err
+}
 
 func main() {
-	hp := ttt.NewHumanPlayer("Calvin", "X")
-	cp := ttt.NewComputerPlayer("HAL9000", "O")
-	g := ttt.NewGame(hp, cp)
+	// Without flags the game is configured through the menu.
+	if len(os.Args) == 1 {
+		g, err := ttt.RunMenu(os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		g.Start()
+		return
+	}
+
+	cfg, err := parseFlags(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		os.Exit(2)
+	}
+
+	g, err := cfg.NewGame(os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	g.Start()
 }
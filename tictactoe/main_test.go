@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/calvinfeng/go-academy/tictactoe/ttt"
+)
+
+func TestParseFlags(t *testing.T) {
+	out := &bytes.Buffer{}
+	usageOutput = out
+
+	t.Run("Valid", func(t *testing.T) {
+		testCases := []struct {
+			args     []string
+			expected ttt.GameConfig
+		}{
+			{
+				args: []string{},
+				expected: ttt.GameConfig{Size: 3, Opponent: ttt.ComputerOpponent,
+					Difficulty: ttt.Hard, First: "X"},
+			},
+			{
+				args: []string{"-size=4", "-ai=hard", "-first=O"},
+				expected: ttt.GameConfig{Size: 4, Opponent: ttt.ComputerOpponent,
+					Difficulty: ttt.Hard, First: "O"},
+			},
+			{
+				args:     []string{"-ai", "Random", "-first", "o"},
+				expected: ttt.GameConfig{Size: 3, Opponent: ttt.RandomOpponent, First: "O"},
+			},
+			{
+				args:     []string{"-size=5", "-ai=human"},
+				expected: ttt.GameConfig{Size: 5, Opponent: ttt.HumanOpponent, First: "X"},
+			},
+		}
+
+		for _, tc := range testCases {
+			cfg, err := parseFlags(tc.args)
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+				continue
+			}
+
+			if cfg != tc.expected {
+				t.Errorf("config %+v != expected config %+v for %v", cfg, tc.expected, tc.args)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"-size=2"},
+			{"-size=four"},
+			{"-ai=chess"},
+			{"-first=Z"},
+			{"-depth=3"},
+			{"-size=4", "extra"},
+		} {
+			out.Reset()
+			if _, err := parseFlags(args); err == nil {
+				t.Errorf("expected error for %v", args)
+			}
+
+			if !strings.Contains(out.String(), "Usage") {
+				t.Errorf("usage should be printed for %v\n%s", args, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/calvinfeng/go-academy/tictactoe/ttt"
+	"github.com/gorilla/websocket"
+)
+
+// Message is sent by the server to the players of a live game.
+//
+//   - "start" tells a player its Mark once an opponent has joined.
+//   - "state" carries the Board before every move, the mark of the board tells whose turn it is.
+//   - "move" relays the Move just made along with the resulting Board.
+//   - "error" tells the player its last move was rejected and why, the player moves again.
+//   - "over" carries the final Board and the Winner, which is empty on a draw.
+//
+// A player sends its moves as a MoveRequest.
+type Message struct {
+	Type   string     `json:"type"`
+	Mark   string     `json:"mark,omitempty"`
+	Move   *ttt.Move  `json:"move,omitempty"`
+	Board  *ttt.Board `json:"board,omitempty"`
+	Winner string     `json:"winner,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// errOpponentLeft is returned by a remote player who is waiting for its move when its opponent
+// disconnects.
+var errOpponentLeft = errors.New("opponent left the game")
+
+var upgrader = websocket.Upgrader{}
+
+// handleLive pairs every two connections into a game between them, the first connection plays X
+// and moves first. A player who disconnects before the game is over loses.
+func (s *Server) handleLive(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	p := newRemotePlayer(conn)
+	go p.listen()
+
+	s.mu.Lock()
+	opponent := s.waiting
+	if opponent == nil {
+		s.waiting = p
+	} else {
+		s.waiting = nil
+	}
+	s.mu.Unlock()
+
+	if opponent == nil {
+		select {
+		case <-p.done:
+			return
+		case <-p.gone:
+			s.mu.Lock()
+			if s.waiting == p {
+				s.waiting = nil
+				s.mu.Unlock()
+				return
+			}
+			s.mu.Unlock()
+			<-p.done
+			return
+		}
+	}
+
+	playLive(opponent, p)
+}
+
+// playLive plays a game between two remote players and tells both when it is over.
+func playLive(p1, p2 *remotePlayer) {
+	defer close(p1.done)
+	defer close(p2.done)
+
+	p1.mark, p2.mark = "X", "O"
+	p1.opponent, p2.opponent = p2, p1
+	p1.send(&Message{Type: "start", Mark: p1.mark})
+	p2.send(&Message{Type: "start", Mark: p2.mark})
+
+	b := ttt.NewBoard(3)
+	g, err := ttt.NewGame(p1, p2, ttt.WithBoard(b), ttt.WithOutput(io.Discard))
+	if err != nil {
+		p1.send(&Message{Type: "error", Error: err.Error()})
+		p2.send(&Message{Type: "error", Error: err.Error()})
+		return
+	}
+	g.AddObserver(&liveObserver{board: b, players: []*remotePlayer{p1, p2}})
+
+	// A player who disconnects on its own turn resigns, which ends the game through the observer.
+	// A player who disconnects on the turn of its opponent is only noticed by the opponent.
+	if _, err := g.Play(); errors.Is(err, errOpponentLeft) {
+		winner := p1
+		if p1.left() {
+			winner = p2
+		}
+		winner.send(&Message{Type: "over", Board: b, Winner: winner.mark})
+	}
+}
+
+// liveObserver broadcasts the progress of a live game to its players.
+type liveObserver struct {
+	board   *ttt.Board
+	players []*remotePlayer
+}
+
+// OnMove implements ttt.Observer.
+func (o *liveObserver) OnMove(p ttt.Player, i, j int) {
+	o.broadcast(&Message{Type: "move", Move: &ttt.Move{I: i, J: j, Mark: p.Mark()}, Board: o.board})
+}
+
+// OnGameOver implements ttt.Observer.
+func (o *liveObserver) OnGameOver(winner ttt.Player) {
+	m := &Message{Type: "over", Board: o.board}
+	if winner != nil {
+		m.Winner = winner.Mark()
+	}
+	o.broadcast(m)
+}
+
+func (o *liveObserver) broadcast(m *Message) {
+	for _, p := range o.players {
+		p.send(m)
+	}
+}
+
+func newRemotePlayer(conn *websocket.Conn) *remotePlayer {
+	return &remotePlayer{
+		conn:  conn,
+		moves: make(chan MoveRequest, 1),
+		gone:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// remotePlayer is a player connected over a WebSocket. Its moves are read by listen and handed to
+// GetMove through a channel, so that a disconnect is noticed even when it is not its turn.
+type remotePlayer struct {
+	conn     *websocket.Conn
+	mark     string
+	opponent *remotePlayer
+
+	// writeMu serializes the writes to the connection.
+	writeMu sync.Mutex
+
+	moves chan MoveRequest
+
+	// gone is closed once the connection is closed, and done once the game is over.
+	gone chan struct{}
+	done chan struct{}
+}
+
+// listen reads moves until the connection is closed. It never blocks on a move, a move that is
+// sent while another one is still pending is rejected.
+func (p *remotePlayer) listen() {
+	defer close(p.gone)
+	for {
+		m := MoveRequest{}
+		if err := p.conn.ReadJSON(&m); err != nil {
+			return
+		}
+
+		select {
+		case p.moves <- m:
+		default:
+			p.send(&Message{Type: "error", Error: "it is not your turn"})
+		}
+	}
+}
+
+func (p *remotePlayer) left() bool {
+	select {
+	case <-p.gone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *remotePlayer) send(m *Message) {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	p.conn.WriteJSON(m)
+}
+
+// GetMove implements ttt.Player. It sends the board to the player and waits for a legal move. It
+// returns ttt.ErrResigned if the player disconnects, and errOpponentLeft if the opponent does.
+func (p *remotePlayer) GetMove(b *ttt.Board) (int, int, error) {
+	// A move sent before the turn of the player is dropped.
+	select {
+	case <-p.moves:
+	default:
+	}
+
+	p.send(&Message{Type: "state", Board: b})
+	for {
+		select {
+		case m := <-p.moves:
+			if !b.IsLegalMove(m.I, m.J) {
+				p.send(&Message{Type: "error", Error: "illegal move, please try again"})
+				continue
+			}
+
+			return m.I, m.J, nil
+		case <-p.gone:
+			return 0, 0, ttt.ErrResigned
+		case <-p.opponent.gone:
+			return 0, 0, errOpponentLeft
+		}
+	}
+}
+
+// Mark implements ttt.Player.
+func (p *remotePlayer) Mark() string {
+	return p.mark
+}
+
+// Name implements ttt.Player.
+func (p *remotePlayer) Name() string {
+	return p.mark
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialLive connects a player to the live endpoint of the server.
+func dialLive(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http")+"/live", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return conn
+}
+
+// playScripted plays the moves in order whenever it is the turn of the player, and returns the
+// winner once the game is over.
+func playScripted(conn *websocket.Conn, moves []MoveRequest, result chan<- string) {
+	mark := ""
+	for {
+		m := &Message{}
+		if err := conn.ReadJSON(m); err != nil {
+			result <- "error: " + err.Error()
+			return
+		}
+
+		switch m.Type {
+		case "start":
+			mark = m.Mark
+		case "state":
+			if m.Board.CurrentMark() != mark || len(moves) == 0 {
+				continue
+			}
+			conn.WriteJSON(moves[0])
+			moves = moves[1:]
+		case "over":
+			result <- m.Winner
+			return
+		}
+	}
+}
+
+func TestLiveGame(t *testing.T) {
+	s := httptest.NewServer(NewServer())
+	defer s.Close()
+
+	x := dialLive(t, s.URL)
+	defer x.Close()
+	o := dialLive(t, s.URL)
+	defer o.Close()
+
+	xResult, oResult := make(chan string, 1), make(chan string, 1)
+	go playScripted(x, []MoveRequest{{I: 0, J: 0}, {I: 0, J: 1}, {I: 0, J: 2}}, xResult)
+	go playScripted(o, []MoveRequest{{I: 1, J: 0}, {I: 1, J: 1}}, oResult)
+
+	if winner := <-xResult; winner != "X" {
+		t.Errorf("winner %q != X for the first player", winner)
+	}
+
+	if winner := <-oResult; winner != "X" {
+		t.Errorf("winner %q != X for the second player", winner)
+	}
+}
+
+func TestLiveGameDisconnect(t *testing.T) {
+	s := httptest.NewServer(NewServer())
+	defer s.Close()
+
+	x := dialLive(t, s.URL)
+	o := dialLive(t, s.URL)
+	defer o.Close()
+
+	// X leaves as soon as the game starts, before making a move.
+	if err := x.ReadJSON(&Message{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x.Close()
+
+	oResult := make(chan string, 1)
+	go playScripted(o, []MoveRequest{{I: 1, J: 1}}, oResult)
+	if winner := <-oResult; winner != "O" {
+		t.Errorf("winner %q != O who stayed", winner)
+	}
+}
@@ -0,0 +1,211 @@
+// Package server exposes tic tac toe games against the computer over a JSON REST API.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/calvinfeng/go-academy/tictactoe/ttt"
+)
+
+const (
+	humanMark    = "X"
+	computerMark = "O"
+)
+
+var difficulties = map[string]ttt.Difficulty{
+	"easy":   ttt.Easy,
+	"medium": ttt.Medium,
+	"hard":   ttt.Hard,
+}
+
+// Option configures optional settings of a server.
+type Option func(*Server)
+
+// WithStore sets the store that the moves of every game are saved to after each request. It
+// defaults to an in memory store.
+func WithStore(store ttt.GameStore) Option {
+	return func(s *Server) {
+		s.store = store
+	}
+}
+
+// NewServer is a constructor for a server without any game.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		games: make(map[string]*session),
+		mux:   http.NewServeMux(),
+		store: ttt.NewMemoryStore(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("POST /games", s.handleCreateGame)
+	s.mux.HandleFunc("POST /games/{id}/moves", s.handleMove)
+	s.mux.HandleFunc("GET /live", s.handleLive)
+	return s
+}
+
+// Server keeps track of the games in progress. In a game against the computer, the human always
+// plays X and moves first, and the computer plays O. Two players can also play each other live
+// over a WebSocket at /live.
+type Server struct {
+	mu     sync.Mutex
+	games  map[string]*session
+	nextID int
+	mux    *http.ServeMux
+	store  ttt.GameStore
+
+	// waiting is the player connected to /live who has no opponent yet.
+	waiting *remotePlayer
+}
+
+// session is a game in progress. The mutex serializes the moves of the game.
+type session struct {
+	mu       sync.Mutex
+	board    *ttt.Board
+	computer *ttt.ComputerPlayer
+}
+
+// CreateGameRequest is the body of POST /games. Difficulty is easy, medium or hard.
+type CreateGameRequest struct {
+	Size       int    `json:"size"`
+	Difficulty string `json:"difficulty"`
+}
+
+// MoveRequest is the body of POST /games/{id}/moves, it places the mark of the human on position
+// (I, J).
+type MoveRequest struct {
+	I int `json:"i"`
+	J int `json:"j"`
+}
+
+// GameResponse is the state of a game. Reply is the move of the computer in response to the move
+// of the human, it is nil if the game ended before the computer moved. Winner is empty unless the
+// game is over and it is not a draw.
+type GameResponse struct {
+	ID     string     `json:"id"`
+	Board  *ttt.Board `json:"board"`
+	Reply  *ttt.Move  `json:"reply,omitempty"`
+	Over   bool       `json:"over"`
+	Winner string     `json:"winner,omitempty"`
+}
+
+// ErrorResponse is the body of every response with an error status.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleCreateGame(w http.ResponseWriter, r *http.Request) {
+	req := &CreateGameRequest{Size: ttt.MinGameSize, Difficulty: "hard"}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if req.Size < ttt.MinGameSize || req.Size > ttt.MaxGameSize {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("board size %d must be between %d and %d",
+			req.Size, ttt.MinGameSize, ttt.MaxGameSize))
+		return
+	}
+
+	difficulty, ok := difficulties[req.Difficulty]
+	if !ok {
+		writeError(w, http.StatusBadRequest,
+			fmt.Errorf("difficulty %q must be easy, medium or hard", req.Difficulty))
+		return
+	}
+
+	computer := ttt.NewComputerPlayer("HAL9000", computerMark, difficulty)
+	if req.Size > 3 {
+		computer.SetMaxDepth(ttt.LargeBoardMaxDepth)
+	}
+	game := &session{board: ttt.NewBoard(req.Size), computer: computer}
+
+	s.mu.Lock()
+	s.nextID++
+	id := strconv.Itoa(s.nextID)
+	s.games[id] = game
+	s.mu.Unlock()
+
+	if err := s.store.Save(id, game.board.History()); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("saving game: %w", err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &GameResponse{ID: id, Board: game.board})
+}
+
+func (s *Server) handleMove(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	game, ok := s.games[id]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("game %q not found", id))
+		return
+	}
+
+	req := &MoveRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.board.IsOver() {
+		writeError(w, http.StatusConflict, errors.New("game is over"))
+		return
+	}
+
+	if err := game.board.PlaceMark(req.I, req.J, humanMark); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := &GameResponse{ID: id, Board: game.board}
+	if !game.board.IsOver() {
+		i, j, err := game.computer.GetMoveContext(r.Context(), game.board)
+		if err != nil {
+			// The move of the human is taken back so that it can be submitted again.
+			game.board.Undo()
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+
+		game.board.PlaceMark(i, j, computerMark)
+		resp.Reply = &ttt.Move{I: i, J: j, Mark: computerMark}
+	}
+
+	if err := s.store.Save(id, game.board.History()); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("saving game: %w", err))
+		return
+	}
+
+	resp.Over = game.board.IsOver()
+	resp.Winner = game.board.Winner()
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/calvinfeng/go-academy/tictactoe/ttt"
+)
+
+func post(t *testing.T, s *Server, path, body string) (*httptest.ResponseRecorder, *GameResponse) {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	resp := &GameResponse{}
+	if w.Code < http.StatusBadRequest {
+		if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return w, resp
+}
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	w, created := post(t, s, "/games", `{"size": 3, "difficulty": "hard"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status %d != %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	if created.ID == "" || created.Board.Size() != 3 {
+		t.Fatalf("game %s should have an ID and a 3 by 3 board", w.Body)
+	}
+
+	w, moved := post(t, s, "/games/"+created.ID+"/moves", `{"i": 1, "j": 1}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d != %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	if moved.Reply == nil {
+		t.Fatalf("response %s should have the reply of the computer", w.Body)
+	}
+
+	history := moved.Board.History()
+	if len(history) != 2 || history[0].I != 1 || history[0].J != 1 || history[0].Mark != "X" {
+		t.Fatalf("history %v should start with the move of the human", history)
+	}
+
+	if history[1] != *moved.Reply || moved.Reply.Mark != "O" {
+		t.Errorf("reply %v != last move %v of the board", moved.Reply, history[1])
+	}
+
+	if moved.Reply.I == 1 && moved.Reply.J == 1 {
+		t.Errorf("reply %v is not legal", moved.Reply)
+	}
+
+	if moved.Over {
+		t.Errorf("game should not be over: %s", w.Body)
+	}
+}
+
+func TestServerStore(t *testing.T) {
+	store := ttt.NewMemoryStore()
+	s := NewServer(WithStore(store))
+	_, created := post(t, s, "/games", `{"size": 3, "difficulty": "hard"}`)
+	_, moved := post(t, s, "/games/"+created.ID+"/moves", `{"i": 1, "j": 1}`)
+
+	moves, err := store.Load(created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history := moved.Board.History()
+	if len(moves) != len(history) {
+		t.Fatalf("saved moves %v != history %v", moves, history)
+	}
+
+	for k := range history {
+		if moves[k] != history[k] {
+			t.Errorf("saved moves %v != history %v", moves, history)
+		}
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	s := NewServer()
+	_, created := post(t, s, "/games", `{"size": 3, "difficulty": "easy"}`)
+
+	testCases := []struct {
+		path     string
+		body     string
+		expected int
+	}{
+		{path: "/games", body: `{"size": 9}`, expected: http.StatusBadRequest},
+		{path: "/games", body: `{"difficulty": "impossible"}`, expected: http.StatusBadRequest},
+		{path: "/games", body: `not json`, expected: http.StatusBadRequest},
+		{path: "/games/42/moves", body: `{"i": 0, "j": 0}`, expected: http.StatusNotFound},
+		{path: "/games/" + created.ID + "/moves", body: `{"i": 3, "j": 0}`,
+			expected: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		if w, _ := post(t, s, tc.path, tc.body); w.Code != tc.expected {
+			t.Errorf("status %d != %d for %s %s: %s", w.Code, tc.expected, tc.path, tc.body, w.Body)
+		}
+	}
+}
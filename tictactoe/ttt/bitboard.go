@@ -0,0 +1,118 @@
+package ttt
+
+import "sync"
+
+// maxMaskCells is the number of cells of the largest board whose cells fit in the bits of a
+// uint64.
+const maxMaskCells = 64
+
+// OccupancyMask returns a bitmask of the positions that hold mark, position (i, j) being bit
+// i*cols+j. It is only defined for boards of up to 64 positions, e.g. 8 by 8, and returns 0 for
+// larger boards.
+func (b *Board) OccupancyMask(mark string) uint64 {
+	if !b.fitsMask() || mark == empty || mark == blocked {
+		return 0
+	}
+
+	var mask uint64
+	for i := range b.grid {
+		for j, cell := range b.grid[i] {
+			if cell == mark {
+				mask |= 1 << uint(i*b.cols+j)
+			}
+		}
+	}
+
+	return mask
+}
+
+// fitsMask checks if every position of the board has a bit in a uint64.
+func (b *Board) fitsMask() bool {
+	return b.rows*b.cols <= maxMaskCells
+}
+
+// maxMaskMarks is the number of marks whose occupancy masks are kept on the stack when looking for
+// a winner, games with more marks allocate them.
+const maxMaskMarks = 4
+
+// occupancyMasks sets masks[k] to the bitmask of the k-th mark of the board, computed in a single
+// pass over the grid. The masks are filled in place so that the search does not allocate. It
+// reports false if a position holds a mark that is not one of the marks of the board.
+func (b *Board) occupancyMasks(masks []uint64) bool {
+	for k := range masks {
+		masks[k] = 0
+	}
+
+	for i := range b.grid {
+		for j, cell := range b.grid[i] {
+			if cell == empty || cell == blocked {
+				continue
+			}
+
+			k := 0
+			for k < len(b.marks) && b.marks[k] != cell {
+				k++
+			}
+
+			if k == len(b.marks) {
+				return false
+			}
+			masks[k] |= 1 << uint(i*b.cols+j)
+		}
+	}
+
+	return true
+}
+
+// lineMaskCache holds the line masks of every board shape and win length seen so far. Boards are
+// searched concurrently, so the cache is safe for concurrent use.
+var lineMaskCache sync.Map
+
+// lineMasks returns the bitmask of every line of b, in the order of shapeLines. The masks are
+// computed once per board shape and win length.
+func (b *Board) lineMasks() []uint64 {
+	key := [3]int{b.rows, b.cols, b.winLength}
+	if masks, ok := lineMaskCache.Load(key); ok {
+		return masks.([]uint64)
+	}
+
+	// The masks are shared by boards of the same shape, so they cover the lines through blocked
+	// positions as well. No mark can fill those.
+	lines := b.shapeLines()
+	masks := make([]uint64, len(lines))
+	for k, line := range lines {
+		for _, pos := range line {
+			masks[k] |= 1 << uint(pos[0]*b.cols+pos[1])
+		}
+	}
+
+	lineMaskCache.Store(key, masks)
+	return masks
+}
+
+// maskWinner returns the mark that fills one of the lines of a board of up to 64 positions,
+// checking the occupancy mask of every mark against the line masks. It returns an empty string if
+// there is no winner.
+func (b *Board) maskWinner() string {
+	var buf [maxMaskMarks]uint64
+	masks := buf[:]
+	if len(b.marks) > len(buf) {
+		masks = make([]uint64, len(b.marks))
+	}
+	masks = masks[:len(b.marks)]
+
+	// A mark left on the board after the marks were changed has no mask.
+	if !b.occupancyMasks(masks) {
+		return b.lineWinner()
+	}
+
+	for _, line := range b.lineMasks() {
+		for k, m := range masks {
+			if m&line == line {
+				return b.marks[k]
+			}
+		}
+	}
+
+	return ""
+}
@@ -1,82 +1,705 @@
 package ttt
 
-// newBoard is a constructor for an empty board.
-func newBoard() *board {
-	var b board
-	for i := 0; i < len(b); i++ {
-		for j := 0; j < len(b[i]); j++ {
-			b[i][j] = "_"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// empty is the mark of an unoccupied position.
+const empty = "_"
+
+// blocked is the mark of a position that cannot be played, see NewBoardWithBlocks.
+const blocked = "#"
+
+// NewBoard is a constructor for an empty size by size board. A player needs to fill a full row,
+// column or diagonal to win.
+func NewBoard(size int) *Board {
+	b, _ := NewBoardWithWin(size, size)
+	return b
+}
+
+// NewBoardWithWin is a constructor for an empty size by size board where a player needs winLength
+// marks in a row to win.
+func NewBoardWithWin(size, winLength int) (*Board, error) {
+	if winLength < 1 || winLength > size {
+		return nil, fmt.Errorf("win length %d must be between 1 and board size %d", winLength, size)
+	}
+
+	return NewRectBoard(size, size, winLength)
+}
+
+// NewRectBoard is a constructor for an empty board of rows by cols positions where a player needs
+// winLength marks in a row to win. It returns an error if the board has no positions or the win
+// length is not between 1 and the shorter side.
+func NewRectBoard(rows, cols, winLength int) (*Board, error) {
+	if rows < 1 || cols < 1 {
+		return nil, fmt.Errorf("board of %d by %d positions must have at least one position", rows,
+			cols)
+	}
+
+	if err := checkWinLength(rows, cols, winLength); err != nil {
+		return nil, err
+	}
+
+	grid := make([][]string, rows)
+	for i := range grid {
+		grid[i] = make([]string, cols)
+		for j := range grid[i] {
+			grid[i][j] = empty
 		}
 	}
 
-	return &b
+	return &Board{
+		rows:      rows,
+		cols:      cols,
+		winLength: winLength,
+		grid:      grid,
+		marks:     []string{"X", "O"},
+		turn:      "X",
+	}, nil
 }
 
-// board is a 3 by 3 grid.
-type board [3][3]string
+// NewBoardWithBlocks is a constructor for an empty size by size board where the positions of blocks
+// are walls. A wall can never be played, and a line that passes through a wall cannot be won, so a
+// player needs to fill a full row, column or diagonal that is free of walls to win. The blocks are
+// positions like the ones GetAvailablePos returns. It returns an error if a block is outside of
+// the board.
+func NewBoardWithBlocks(size int, blocks []Position) (*Board, error) {
+	b, err := NewBoardWithWin(size, size)
+	if err != nil {
+		return nil, err
+	}
 
-// String returns the string representation of a board.
-func (b *board) String() string {
-	str := "    "
-	for i := range b {
-		for j := range b[i] {
-			str += b[i][j] + " "
+	if err := b.block(blocks); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// block turns the positions into walls.
+func (b *Board) block(positions []Position) error {
+	for _, pos := range positions {
+		if !b.inRange(pos.Row, pos.Col) {
+			return fmt.Errorf("block (%d, %d) is outside of the %d by %d board", pos.Row, pos.Col,
+				b.rows, b.cols)
 		}
-		str += "\n    "
+
+		b.grid[pos.Row][pos.Col] = blocked
+		b.hasBlocks = true
 	}
-	return str
+
+	return nil
+}
+
+// Board is a grid of rows by cols positions, most boards are square.
+type Board struct {
+	rows      int
+	cols      int
+	winLength int
+	grid      [][]string
+	history   []Move
+	marks     []string
+
+	// turn is the mark to move next. It is kept up to date by PlaceMark and Undo, but PlaceMark
+	// does not enforce it.
+	turn string
+
+	// hasBlocks is set if some positions are walls, which the lines of the board have to avoid.
+	hasBlocks bool
+}
+
+// Size returns the number of rows, which is also the number of columns, of a square board. Use Rows
+// and Cols for a rectangular board.
+func (b *Board) Size() int {
+	return b.rows
+}
+
+// Rows returns the number of rows of the board.
+func (b *Board) Rows() int {
+	return b.rows
+}
+
+// Cols returns the number of columns of the board.
+func (b *Board) Cols() int {
+	return b.cols
+}
+
+// isSquare checks if the board has as many rows as columns.
+func (b *Board) isSquare() bool {
+	return b.rows == b.cols
+}
+
+// WinLength returns the number of marks in a row needed to win.
+func (b *Board) WinLength() int {
+	return b.winLength
+}
+
+// SetWinLength changes the number of marks in a row needed to win, e.g. to experiment with an
+// existing position. The marks stay in place and the winner is decided by the new lines. It returns
+// an error if a line of n marks does not fit on the board, see checkWinLength.
+func (b *Board) SetWinLength(n int) error {
+	if err := checkWinLength(b.rows, b.cols, n); err != nil {
+		return err
+	}
+
+	b.winLength = n
+	return nil
 }
 
-func (b *board) isWon() bool {
-	if b.winner() != "" {
-		return true
+// checkWinLength returns an error unless winLength is between 1 and the shorter side of a board of
+// rows by cols positions, so that a line of winLength marks fits in every direction.
+func checkWinLength(rows, cols, winLength int) error {
+	if winLength < 1 || winLength > min(rows, cols) {
+		return fmt.Errorf("win length %d must be between 1 and the shorter side %d", winLength,
+			min(rows, cols))
 	}
+
+	return nil
+}
+
+// Marks returns the marks of the players in the order they take turns. They are X and O unless set
+// otherwise.
+func (b *Board) Marks() []string {
+	marks := make([]string, len(b.marks))
+	copy(marks, b.marks)
+	return marks
+}
+
+// SetMarks sets the marks of the players in the order they take turns. If the marks change, the
+// first mark moves next on an empty board, and on a board with moves the mark to move is kept if it
+// is still one of the marks. It returns an error unless there are at least two marks, and they are
+// distinct and not empty.
+func (b *Board) SetMarks(marks ...string) error {
+	if len(marks) < 2 {
+		return fmt.Errorf("expected at least 2 marks, got %d", len(marks))
+	}
+
+	seen := make(map[string]bool)
+	for _, mark := range marks {
+		if mark == "" || mark == empty {
+			return fmt.Errorf("mark %q is reserved for empty positions", mark)
+		}
+
+		if mark == blocked {
+			return fmt.Errorf("mark %q is reserved for blocked positions", mark)
+		}
+
+		if seen[mark] {
+			return fmt.Errorf("marks must be distinct, got %q twice", mark)
+		}
+		seen[mark] = true
+	}
+
+	changed := len(marks) != len(b.marks)
+	for k := 0; !changed && k < len(marks); k++ {
+		changed = marks[k] != b.marks[k]
+	}
+
+	b.marks = append([]string{}, marks...)
+	if changed && (len(b.history) == 0 || !b.isMark(b.turn)) {
+		b.turn = marks[0]
+	}
+
+	return nil
+}
+
+// CurrentMark returns the mark to move next. It is the first mark on a new board, and every move
+// placed passes the turn to the mark that plays after it.
+func (b *Board) CurrentMark() string {
+	return b.turn
+}
+
+// SetStartingMark sets the mark to move next, e.g. to let the second player start on an empty
+// board. It returns an error if mark is not one of the marks of the board.
+func (b *Board) SetStartingMark(mark string) error {
+	if !b.isMark(mark) {
+		return fmt.Errorf("mark %q is not in the game", mark)
+	}
+
+	b.turn = mark
+	return nil
+}
+
+// isMark checks if mark is one of the marks of the board.
+func (b *Board) isMark(mark string) bool {
+	for _, m := range b.marks {
+		if m == mark {
+			return true
+		}
+	}
+
 	return false
 }
 
-// Winner returns the winner of the board.
-func (b *board) winner() string {
-	xStreak := [3]string{"X", "X", "X"}
-	oStreak := [3]string{"O", "O", "O"}
+// Opponent returns the mark that plays after mark. It returns an empty string if mark is not one of
+// the marks of the board.
+func (b *Board) Opponent(mark string) string {
+	for k := range b.marks {
+		if b.marks[k] == mark {
+			return b.marks[(k+1)%len(b.marks)]
+		}
+	}
 
-	rows := b.rows()
-	for i := range rows {
-		if rows[i] == xStreak {
-			return "X"
+	return ""
+}
+
+// String returns the string representation of a board. Rows are separated by lines of dashes and
+// columns by pipes, and every cell is as wide as the longest mark so that columns stay aligned.
+// Empty positions are rendered as spaces.
+func (b *Board) String() string {
+	return b.format(func(mark string) string { return mark })
+}
+
+// format lays out the board like String, with every mark passed through decorate. Cells are padded
+// according to the marks themselves, so decorate can add invisible text such as escape codes
+// without breaking the alignment.
+func (b *Board) format(decorate func(mark string) string) string {
+	width := 1
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if n := utf8.RuneCountInString(b.grid[i][j]); b.grid[i][j] != empty && n > width {
+				width = n
+			}
 		}
-		if rows[i] == oStreak {
-			return "O"
+	}
+
+	cells := make([]string, b.cols)
+	for j := range cells {
+		cells[j] = strings.Repeat("-", width+2)
+	}
+	separator := strings.Join(cells, "+")
+
+	rows := make([]string, b.rows)
+	for i := range b.grid {
+		for j, mark := range b.grid[i] {
+			if mark == empty {
+				cells[j] = " " + strings.Repeat(" ", width) + " "
+				continue
+			}
+
+			padding := strings.Repeat(" ", width-utf8.RuneCountInString(mark))
+			cells[j] = " " + decorate(mark) + padding + " "
+		}
+		rows[i] = strings.Join(cells, "|")
+	}
+
+	return strings.Join(rows, "\n"+separator+"\n")
+}
+
+// IsLegalMove checks if position (i, j) is on the board and not yet occupied.
+func (b *Board) IsLegalMove(i, j int) bool {
+	return b.checkMove(i, j) == nil
+}
+
+func (b *Board) inRange(i, j int) bool {
+	return i >= 0 && i < b.rows && j >= 0 && j < b.cols
+}
+
+// MarkAt returns the mark on position (i, j), or an empty string if no mark occupies the position,
+// including a blocked position. It returns an error if the position is outside of the board.
+func (b *Board) MarkAt(i, j int) (string, error) {
+	if !b.inRange(i, j) {
+		return "", fmt.Errorf("position (%d, %d) is outside of the %d by %d board", i, j, b.rows,
+			b.cols)
+	}
+
+	if b.grid[i][j] == empty || b.grid[i][j] == blocked {
+		return "", nil
+	}
+
+	return b.grid[i][j], nil
+}
+
+// IsBlocked checks if position (i, j) is a wall that cannot be played, see NewBoardWithBlocks.
+func (b *Board) IsBlocked(i, j int) bool {
+	return b.inRange(i, j) && b.grid[i][j] == blocked
+}
+
+// ForEachCell calls fn with every position of the board and its mark, an empty string if no mark
+// occupies the position, including a blocked position. Positions are visited in row-major order:
+// left to right along the first row, then along the second row, and so on.
+func (b *Board) ForEachCell(fn func(i, j int, mark string)) {
+	for i := range b.grid {
+		for j, mark := range b.grid[i] {
+			if mark == empty || mark == blocked {
+				mark = ""
+			}
+			fn(i, j, mark)
+		}
+	}
+}
+
+// checkMove returns an IllegalMoveError if a mark cannot be placed on position (i, j).
+func (b *Board) checkMove(i, j int) error {
+	if !b.inRange(i, j) {
+		return &IllegalMoveError{I: i, J: j, Reason: "position out of range"}
+	}
+
+	if b.grid[i][j] == blocked {
+		return &IllegalMoveError{I: i, J: j, Reason: "cell is blocked"}
+	}
+
+	if b.grid[i][j] != empty {
+		return &IllegalMoveError{I: i, J: j, Reason: "cell already taken"}
+	}
+
+	return nil
+}
+
+// PlaceMark puts a mark on position (i, j). It returns an IllegalMoveError if the position is
+// outside of the board, it is already occupied, or mark is not one of the marks of the board.
+func (b *Board) PlaceMark(i, j int, mark string) error {
+	if err := b.checkMove(i, j); err != nil {
+		return err
+	}
+
+	if !b.isMark(mark) {
+		return &IllegalMoveError{I: i, J: j, Reason: fmt.Sprintf("mark %q is not in the game", mark)}
+	}
+
+	b.grid[i][j] = mark
+	b.history = append(b.history, Move{I: i, J: j, Mark: mark})
+	b.turn = b.Opponent(mark)
+	return nil
+}
+
+// ApplyMoves places the moves in order. A move without a mark is played by the mark to move. It
+// stops at the first illegal move and returns an error carrying the index of that move, the moves
+// before it stay on the board.
+func (b *Board) ApplyMoves(moves []Move) error {
+	for k, m := range moves {
+		mark := m.Mark
+		if mark == "" {
+			mark = b.CurrentMark()
+		}
+
+		if err := b.PlaceMark(m.I, m.J, mark); err != nil {
+			return fmt.Errorf("move %d: %w", k, err)
 		}
 	}
 
-	columns := b.columns()
-	for i := range columns {
-		if columns[i] == xStreak {
-			return "X"
+	return nil
+}
+
+// ValidateMoves checks the moves in order as if they were placed on the board, without changing the
+// board. It returns an error for every move, nil if the move is legal and otherwise an
+// IllegalMoveError saying why: the position is outside of the board or occupied, the mark is not
+// the mark to move, or the game is already over. A move without a mark is played by the mark to
+// move. An illegal move is skipped, so the moves after it are checked as if it was never made.
+func (b *Board) ValidateMoves(moves []Move) []error {
+	c := b.Copy()
+	errs := make([]error, len(moves))
+	for k, m := range moves {
+		mark := m.Mark
+		if mark == "" {
+			mark = c.CurrentMark()
+		}
+
+		switch {
+		case c.IsOver():
+			errs[k] = &IllegalMoveError{I: m.I, J: m.J, Reason: "game is over"}
+		case c.isMark(mark) && mark != c.CurrentMark():
+			errs[k] = &IllegalMoveError{I: m.I, J: m.J,
+				Reason: fmt.Sprintf("it is the turn of %s, not %s", c.CurrentMark(), mark)}
+		default:
+			errs[k] = c.PlaceMark(m.I, m.J, mark)
+		}
+	}
+
+	return errs
+}
+
+// PlaceMarkChecked puts a mark on position (i, j) like PlaceMark, and reports whether the game is
+// over after the move along with the mark of the winner, which is empty if there is none. Only the
+// lines through (i, j) are checked for a winner, see WinnerAfter. The board is left unchanged if
+// the move is illegal.
+func (b *Board) PlaceMarkChecked(i, j int, mark string) (over bool, winner string, err error) {
+	if err := b.PlaceMark(i, j, mark); err != nil {
+		return false, "", err
+	}
+
+	winner = b.WinnerAfter(i, j)
+	return winner != "" || b.Full(), winner, nil
+}
+
+// History returns the moves placed on the board in the order they were played.
+func (b *Board) History() []Move {
+	history := make([]Move, len(b.history))
+	copy(history, b.history)
+	return history
+}
+
+// LastMove returns the most recently placed move. It returns false if no mark has been placed.
+func (b *Board) LastMove() (i, j int, mark string, ok bool) {
+	if len(b.history) == 0 {
+		return 0, 0, "", false
+	}
+
+	last := b.history[len(b.history)-1]
+	return last.I, last.J, last.Mark, true
+}
+
+// Undo removes the most recently placed mark. It returns an error if no mark has been placed.
+func (b *Board) Undo() error {
+	if len(b.history) == 0 {
+		return errors.New("there is no move to undo")
+	}
+
+	last := b.history[len(b.history)-1]
+	b.grid[last.I][last.J] = empty
+	b.history = b.history[:len(b.history)-1]
+	b.turn = last.Mark
+	return nil
+}
+
+// Reset clears every position and the history so that the board can be reused for a new game, and
+// the first mark moves next. The size, the win length, the marks and the blocked positions are
+// kept.
+func (b *Board) Reset() {
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if b.grid[i][j] != blocked {
+				b.grid[i][j] = empty
+			}
+		}
+	}
+
+	b.history = b.history[:0]
+	b.turn = b.marks[0]
+}
+
+// IsOver checks if there is a winner or the board is full.
+func (b *Board) IsOver() bool {
+	return b.Winner() != "" || b.Full()
+}
+
+// IsDraw checks if the board is full and there is no winner.
+func (b *Board) IsDraw() bool {
+	return b.Full() && b.Winner() == ""
+}
+
+// PossibleWinners returns the marks, in the order they take turns, that hold every occupied
+// position of at least one line, so that they could still complete it. An empty slice means a dead
+// draw, no line can be completed by anyone even though positions may be left.
+func (b *Board) PossibleWinners() []string {
+	open := make(map[string]bool)
+	for _, line := range b.lines() {
+		owner := ""
+		contested := false
+		for _, pos := range line {
+			mark := b.grid[pos[0]][pos[1]]
+			if mark == empty {
+				continue
+			}
+
+			if owner != "" && owner != mark {
+				contested = true
+				break
+			}
+			owner = mark
 		}
-		if columns[i] == oStreak {
-			return "O"
+
+		if contested {
+			continue
 		}
+
+		// An empty line can be completed by every mark.
+		if owner == "" {
+			return b.Marks()
+		}
+		open[owner] = true
 	}
 
-	diagonals := b.diagonals()
-	for i := range diagonals {
-		if diagonals[i] == xStreak {
-			return "X"
+	winners := []string{}
+	for _, mark := range b.marks {
+		if open[mark] {
+			winners = append(winners, mark)
 		}
-		if diagonals[i] == oStreak {
-			return "O"
+	}
+
+	return winners
+}
+
+// IsForcedDraw checks if neither side can force a win when both play optimally from the current
+// position, with toMove being the mark to move next. It searches the whole game tree without a
+// depth limit or a node budget, which is quick on a 3 by 3 board but can take a long time on larger
+// boards.
+func (b *Board) IsForcedDraw(toMove string) bool {
+	if b.IsOver() {
+		return b.IsDraw()
+	}
+
+	cp := NewComputerPlayer("", toMove, Hard)
+	m, _ := cp.minimax(newSearch(context.Background(), 0), b, toMove, 1, math.MinInt32, math.MaxInt32)
+	return m.value == 0
+}
+
+// Winner returns the mark that has winLength marks in a row, horizontally, vertically or
+// diagonally. It returns an empty string if there is no winner. Boards up to 8 by 8 are checked
+// with bitmasks, larger boards only check the cells of the precomputed lines.
+func (b *Board) Winner() string {
+	if b.fitsMask() {
+		return b.maskWinner()
+	}
+
+	return b.lineWinner()
+}
+
+// lineWinner returns the winner like Winner, checking the cells of every line.
+func (b *Board) lineWinner() string {
+	for _, line := range b.lines() {
+		if b.isStreak(line) {
+			return b.grid[line[0][0]][line[0][1]]
 		}
 	}
 
 	return ""
 }
 
-func (b *board) emptyCount() int {
+// WinningLine returns the positions of the marks that won the board. It returns an empty slice if
+// there is no winner.
+func (b *Board) WinningLine() [][2]int {
+	for _, line := range b.lines() {
+		if b.isStreak(line) {
+			winning := make([][2]int, len(line))
+			copy(winning, line)
+			return winning
+		}
+	}
+
+	return [][2]int{}
+}
+
+// WinningMoves returns every empty position that would complete a line of mark if mark were placed
+// on it. More than one position means mark has a fork.
+func (b *Board) WinningMoves(mark string) []Position {
+	winning := make(map[Position]bool)
+	for _, line := range b.lines() {
+		count, open := 0, [][2]int{}
+		for _, pos := range line {
+			switch b.grid[pos[0]][pos[1]] {
+			case mark:
+				count++
+			case empty:
+				open = append(open, pos)
+			}
+		}
+
+		if count == b.winLength-1 && len(open) == 1 {
+			winning[Position{Row: open[0][0], Col: open[0][1]}] = true
+		}
+	}
+
+	moves := []Position{}
+	for _, pos := range b.GetAvailablePos() {
+		if winning[pos] {
+			moves = append(moves, pos)
+		}
+	}
+
+	return moves
+}
+
+// IsWinningMove checks if placing mark on position (i, j) would complete a line of mark. The board
+// is not changed. It returns false if the move is illegal or mark is not one of the marks of the
+// board.
+func (b *Board) IsWinningMove(i, j int, mark string) bool {
+	if b.checkMove(i, j) != nil || !b.isMark(mark) {
+		return false
+	}
+
+	return b.completesLine(i, j, mark)
+}
+
+// WinnerAfter returns the mark on position (i, j) if it is part of a line of winLength marks, and
+// an empty string otherwise. Only the lines through (i, j) are checked, so right after a move on a
+// board that had no winner before, it agrees with Winner at a fraction of the cost.
+func (b *Board) WinnerAfter(i, j int) string {
+	mark, err := b.MarkAt(i, j)
+	if err != nil || mark == "" || !b.completesLine(i, j, mark) {
+		return ""
+	}
+
+	return mark
+}
+
+// completesLine checks if mark on position (i, j) would be part of a line of winLength marks,
+// whatever is on (i, j) now.
+func (b *Board) completesLine(i, j int, mark string) bool {
+	// The run of mark through (i, j) is counted in both directions of every line.
+	for _, d := range directions {
+		count := 1
+		for _, sign := range []int{1, -1} {
+			for k := 1; ; k++ {
+				r, c := i+sign*d[0]*k, j+sign*d[1]*k
+				if !b.inRange(r, c) || b.grid[r][c] != mark {
+					break
+				}
+				count++
+			}
+		}
+
+		if count >= b.winLength {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BlockingMoves returns every empty position where the mark that plays after myMark would complete
+// a line on its next turn, i.e. the positions myMark has to take to block it. It returns an empty
+// slice if myMark is not one of the marks of the board.
+func (b *Board) BlockingMoves(myMark string) []Position {
+	opponent := b.Opponent(myMark)
+	if opponent == "" {
+		return []Position{}
+	}
+
+	return b.WinningMoves(opponent)
+}
+
+// CreatesFork checks if placing mark on position (i, j) leaves mark with two or more winning
+// moves, so that the opponent cannot block all of them. It returns false if the move is illegal.
+func (b *Board) CreatesFork(i, j int, mark string) bool {
+	c := b.Copy()
+	if err := c.PlaceMark(i, j, mark); err != nil {
+		return false
+	}
+
+	return len(c.WinningMoves(mark)) >= 2
+}
+
+// isStreak checks if every position of a line holds the same mark.
+func (b *Board) isStreak(line [][2]int) bool {
+	first := b.grid[line[0][0]][line[0][1]]
+	if first == empty {
+		return false
+	}
+
+	for _, pos := range line {
+		if b.grid[pos[0]][pos[1]] != first {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EmptyCount returns the number of empty positions. It scans the board, so it takes time in
+// proportion to the number of positions.
+func (b *Board) EmptyCount() int {
 	count := 0
-	for i := range b {
-		for j := range b[i] {
-			if b[i][j] == "_" {
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if b.grid[i][j] == empty {
 				count++
 			}
 		}
@@ -85,49 +708,290 @@ func (b *board) emptyCount() int {
 	return count
 }
 
-func (b *board) rows() [3][3]string {
-	return *b
+// Full checks if every position is occupied. Like EmptyCount, it scans the board.
+func (b *Board) Full() bool {
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if b.grid[i][j] == empty {
+				return false
+			}
+		}
+	}
+
+	return true
 }
 
-func (b *board) columns() [3][3]string {
-	columns := [3][3]string{}
-	for i := range b {
-		for j := range b[i] {
-			columns[j][i] = b[i][j]
+// directions are the steps to walk along a row, a column, a diagonal and an anti-diagonal.
+var directions = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// lineCache holds the lines of every board shape and win length seen so far, so that boards of the
+// same dimensions, e.g. the copies made by a search, share them. It is safe for concurrent use.
+var lineCache sync.Map
+
+// lines returns the positions of every run of winLength cells on the board that does not pass
+// through a blocked position. The lines are computed once per board shape and win length and
+// shared, callers must not modify them.
+func (b *Board) lines() [][][2]int {
+	lines := b.shapeLines()
+	if !b.hasBlocks {
+		return lines
+	}
+
+	open := make([][][2]int, 0, len(lines))
+	for _, line := range lines {
+		walled := false
+		for _, pos := range line {
+			walled = walled || b.grid[pos[0]][pos[1]] == blocked
+		}
+
+		if !walled {
+			open = append(open, line)
+		}
+	}
+
+	return open
+}
+
+// shapeLines returns the positions of every run of winLength cells on a board of the shape of b,
+// regardless of blocked positions.
+func (b *Board) shapeLines() [][][2]int {
+	key := [3]int{b.rows, b.cols, b.winLength}
+	if lines, ok := lineCache.Load(key); ok {
+		return lines.([][][2]int)
+	}
+
+	lines := computeLines(b.rows, b.cols, b.winLength)
+	lineCache.Store(key, lines)
+	return lines
+}
+
+// computeLines returns the positions of every run of winLength cells on a board of rows by cols
+// positions.
+func computeLines(rows, cols, winLength int) [][][2]int {
+	lines := [][][2]int{}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			for _, d := range directions {
+				endI, endJ := i+d[0]*(winLength-1), j+d[1]*(winLength-1)
+				if endI < 0 || endI >= rows || endJ < 0 || endJ >= cols {
+					continue
+				}
+
+				line := make([][2]int, winLength)
+				for k := range line {
+					line[k] = [2]int{i + d[0]*k, j + d[1]*k}
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return lines
+}
+
+// Equal checks if both boards have the same shape, win length, marks on every position and mark to
+// move. The history is not compared, boards reached by different orders of the same moves are
+// equal.
+func (b *Board) Equal(other *Board) bool {
+	if b.rows != other.rows || b.cols != other.cols || b.winLength != other.winLength ||
+		b.turn != other.turn {
+		return false
+	}
+
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if b.grid[i][j] != other.grid[i][j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Key returns a string that uniquely identifies the marks on the board. Boards of the same shape
+// with the same marks on the same positions share the same key. The key does not include the mark
+// to move, callers that need it should pair the key with the mark, e.g. as an inner map key.
+func (b *Board) Key() string {
+	rows := make([]string, b.rows)
+	for i := range b.grid {
+		rows[i] = strings.Join(b.grid[i], ",")
+	}
+
+	return strings.Join(rows, "/")
+}
+
+// CanonicalForm returns the lexicographically smallest key among the 8 rotations and reflections
+// of the board. Boards that are symmetric to each other share the same canonical form. A
+// rectangular board only has the 4 symmetries that keep its shape.
+func (b *Board) CanonicalForm() string {
+	canonical := ""
+	for k := 0; k < 8; k++ {
+		// A quarter turn swaps the rows and the columns of a rectangular board.
+		if k%2 == 1 && !b.isSquare() {
+			continue
+		}
+
+		if key := b.transform(k).Key(); k == 0 || key < canonical {
+			canonical = key
+		}
+	}
+
+	return canonical
+}
+
+// Rotate90 returns a copy of the board rotated by a quarter turn clockwise. The history is rotated
+// along with the marks. A board of rows by cols positions becomes a board of cols by rows
+// positions.
+func (b *Board) Rotate90() *Board {
+	return b.transform(1)
+}
+
+// MirrorHorizontal returns a copy of the board with its columns in reverse order, i.e. mirrored
+// along the vertical axis. The history is mirrored along with the marks. Together with Rotate90 it
+// produces all 8 symmetries of the board.
+func (b *Board) MirrorHorizontal() *Board {
+	return b.transform(4)
+}
+
+// transform returns a copy of the board with every position, including the positions of the
+// history, mapped to its k-th symmetric position.
+func (b *Board) transform(k int) *Board {
+	t := b.Copy()
+	if k%2 == 1 {
+		t.rows, t.cols = b.cols, b.rows
+		t.grid = make([][]string, t.rows)
+		for i := range t.grid {
+			t.grid[i] = make([]string, t.cols)
 		}
 	}
-	return columns
+
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			ti, tj := symmetricPos(i, j, b.rows, b.cols, k)
+			t.grid[ti][tj] = b.grid[i][j]
+		}
+	}
+
+	for n, m := range t.history {
+		t.history[n].I, t.history[n].J = symmetricPos(m.I, m.J, b.rows, b.cols, k)
+	}
+
+	return t
 }
 
-func (b *board) diagonals() [2][3]string {
-	diagonals := [2][3]string{}
-	for i := range b {
-		diagonals[0][i] = b[i][i]
+// symmetricPos maps position (i, j) of a grid of rows by cols positions to its k-th symmetric
+// position. The first 4 symmetries are rotations by k quarter turns and the last 4 are the same
+// rotations of the horizontally mirrored grid. Every quarter turn swaps the rows and the columns.
+func symmetricPos(i, j, rows, cols, k int) (int, int) {
+	if k >= 4 {
+		j = cols - 1 - j
 	}
 
-	for i := range b {
-		diagonals[1][i] = b[i][2-i]
+	for r := 0; r < k%4; r++ {
+		i, j = j, rows-1-i
+		rows, cols = cols, rows
 	}
 
-	return diagonals
+	return i, j
 }
 
-// copy creates a copy of the original board.
-func (b board) copy() *board {
-	return &b
+// Copy creates a deep copy of the original board, including its shape, win length, marks, mark to
+// move and history.
+func (b *Board) Copy() *Board {
+	c := &Board{}
+	b.CopyInto(c)
+	return c
 }
 
-// getAvailablePos returns all empty spots of the board. This is needed for bonus phase: Minimax
-// algorithm
-func (b *board) getAvailablePos() [][2]int {
-	availPos := [][2]int{}
-	for i := range b {
-		for j := range b[i] {
-			if b[i][j] == "_" {
-				availPos = append(availPos, [2]int{i, j})
+// CopyInto makes dst a deep copy of the board. It reuses the memory of dst where it can, so that
+// copying into a board that is no longer needed allocates less than Copy.
+func (b *Board) CopyInto(dst *Board) {
+	if len(dst.grid) != b.rows || (b.rows > 0 && len(dst.grid[0]) != b.cols) {
+		// A single array backs every row so that a new grid takes two allocations.
+		cells := make([]string, b.rows*b.cols)
+		dst.grid = make([][]string, b.rows)
+		for i := range dst.grid {
+			dst.grid[i] = cells[i*b.cols : (i+1)*b.cols : (i+1)*b.cols]
+		}
+	}
+
+	for i := range b.grid {
+		copy(dst.grid[i], b.grid[i])
+	}
+
+	// Every field of the board is copied, TestCopyConfiguration checks that none is left out.
+	dst.rows = b.rows
+	dst.cols = b.cols
+	dst.winLength = b.winLength
+	dst.history = append(dst.history[:0], b.history...)
+	dst.marks = append(dst.marks[:0], b.marks...)
+	dst.turn = b.turn
+	dst.hasBlocks = b.hasBlocks
+}
+
+// boardPool holds boards that the search is done with, so that their memory is reused for the
+// next positions.
+var boardPool = sync.Pool{
+	New: func() interface{} {
+		return &Board{}
+	},
+}
+
+// pooledCopy returns a deep copy of the board made from the pool. It should be given back with
+// releaseBoard once it is no longer used.
+func (b *Board) pooledCopy() *Board {
+	c := boardPool.Get().(*Board)
+	b.CopyInto(c)
+	return c
+}
+
+// releaseBoard gives a board made by pooledCopy back to the pool.
+func releaseBoard(b *Board) {
+	boardPool.Put(b)
+}
+
+// GetAvailablePos returns all empty spots of the board in row by row order. This is needed for
+// bonus phase: Minimax algorithm
+func (b *Board) GetAvailablePos() []Position {
+	availPos := []Position{}
+	for i := range b.grid {
+		for j := range b.grid[i] {
+			if b.grid[i][j] == empty {
+				availPos = append(availPos, Position{Row: i, Col: j})
 			}
 		}
 	}
 
 	return availPos
 }
+
+// LegalMoves returns the positions where mark can be placed right now in row by row order. They
+// are the empty positions, which leaves out blocked positions, if it is the turn of mark and the
+// game is not over. Otherwise there are none.
+func (b *Board) LegalMoves(mark string) []Position {
+	if mark != b.turn || b.IsOver() {
+		return []Position{}
+	}
+
+	return b.GetAvailablePos()
+}
+
+// GetAvailablePosOrdered returns all empty spots of the board sorted by their distance from the
+// center of the board, closest first. Spots at the same distance keep their row by row order.
+// Searching central spots first lets the search prune more of the game tree.
+func (b *Board) GetAvailablePosOrdered() []Position {
+	availPos := b.GetAvailablePos()
+	sort.SliceStable(availPos, func(x, y int) bool {
+		return b.centerDistance(availPos[x]) < b.centerDistance(availPos[y])
+	})
+
+	return availPos
+}
+
+// centerDistance returns the squared distance of a position from the center of the board, doubled
+// on both axes so that it stays an integer on boards of even size.
+func (b *Board) centerDistance(pos Position) int {
+	di, dj := 2*pos.Row-(b.rows-1), 2*pos.Col-(b.cols-1)
+	return di*di + dj*dj
+}
@@ -0,0 +1,144 @@
+package ttt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// boardJSONVersion is the version of the JSON representation of a board. It is raised whenever a
+// change of the representation would make older code misread it.
+const boardJSONVersion = 1
+
+// boardJSON is the JSON representation of a board. Empty positions are encoded as empty strings,
+// and the history lists the moves in the order they were played. Turn is the mark to move next, if
+// it is missing the turn passes from the last move of the history. Square boards are encoded with
+// their size, rectangular boards with their rows and columns. Blocks lists the blocked positions,
+// which are encoded as empty strings in the grid. Version is the version of the representation,
+// documents without a version were written before it was added and are read as version 1.
+type boardJSON struct {
+	Version   int        `json:"version"`
+	Size      int        `json:"size,omitempty"`
+	Rows      int        `json:"rows,omitempty"`
+	Cols      int        `json:"cols,omitempty"`
+	WinLength int        `json:"win_length"`
+	Grid      [][]string `json:"grid"`
+	History   []Move     `json:"history"`
+	Marks     []string   `json:"marks,omitempty"`
+	Turn      string     `json:"turn,omitempty"`
+	Blocks    []Position `json:"blocks,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Board) MarshalJSON() ([]byte, error) {
+	grid := make([][]string, b.rows)
+	for i := range grid {
+		grid[i] = make([]string, b.cols)
+	}
+	var blocks []Position
+	b.ForEachCell(func(i, j int, mark string) {
+		grid[i][j] = mark
+		if b.IsBlocked(i, j) {
+			blocks = append(blocks, Position{Row: i, Col: j})
+		}
+	})
+
+	aux := &boardJSON{
+		Version:   boardJSONVersion,
+		WinLength: b.winLength,
+		Grid:      grid,
+		History:   b.History(),
+		Marks:     b.Marks(),
+		Turn:      b.turn,
+		Blocks:    blocks,
+	}
+	if b.isSquare() {
+		aux.Size = b.rows
+	} else {
+		aux.Rows, aux.Cols = b.rows, b.cols
+	}
+
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It returns an error if the document is of a newer
+// version than this package can read, the grid does not match the size of the board or a move of
+// the history does not match the mark on its position.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	aux := &boardJSON{}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	if aux.Version < 0 || aux.Version > boardJSONVersion {
+		return fmt.Errorf("board version %d is not supported, expected version %d or older",
+			aux.Version, boardJSONVersion)
+	}
+
+	rows, cols := aux.Rows, aux.Cols
+	if rows == 0 && cols == 0 {
+		rows, cols = aux.Size, aux.Size
+	}
+
+	newBoard, err := NewRectBoard(rows, cols, aux.WinLength)
+	if err != nil {
+		return err
+	}
+
+	if aux.Marks != nil {
+		if err := newBoard.SetMarks(aux.Marks...); err != nil {
+			return err
+		}
+	}
+
+	if err := newBoard.block(aux.Blocks); err != nil {
+		return err
+	}
+
+	if len(aux.Grid) != rows {
+		return fmt.Errorf("grid has %d rows, expected %d", len(aux.Grid), rows)
+	}
+
+	for i := range aux.Grid {
+		if len(aux.Grid[i]) != cols {
+			return fmt.Errorf("row %d has %d columns, expected %d", i, len(aux.Grid[i]), cols)
+		}
+
+		for j, mark := range aux.Grid[i] {
+			if mark == empty {
+				return errors.New("empty positions must be encoded as empty strings")
+			}
+
+			if mark != "" && !newBoard.isMark(mark) {
+				return fmt.Errorf("mark %q of position (%d, %d) is not in the game", mark, i, j)
+			}
+
+			if mark != "" && newBoard.IsBlocked(i, j) {
+				return fmt.Errorf("mark %q of position (%d, %d) is on a block", mark, i, j)
+			}
+
+			if mark != "" {
+				newBoard.grid[i][j] = mark
+			}
+		}
+	}
+
+	for _, m := range aux.History {
+		if !newBoard.inRange(m.I, m.J) || newBoard.grid[m.I][m.J] != m.Mark {
+			return fmt.Errorf("history move %s (%d, %d) does not match the grid", m.Mark, m.I, m.J)
+		}
+	}
+	newBoard.history = aux.History
+
+	switch {
+	case aux.Turn != "":
+		if err := newBoard.SetStartingMark(aux.Turn); err != nil {
+			return err
+		}
+	case len(aux.History) > 0:
+		newBoard.turn = newBoard.Opponent(aux.History[len(aux.History)-1].Mark)
+	}
+
+	*b = *newBoard
+	return nil
+}
@@ -0,0 +1,130 @@
+package ttt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBoardJSON(t *testing.T) {
+	b, _ := NewBoardWithWin(4, 3)
+	b.PlaceMark(1, 1, "X")
+	b.PlaceMark(0, 3, "O")
+	b.PlaceMark(2, 2, "X")
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded := NewBoard(3)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !loaded.Equal(b) {
+		t.Errorf("loaded board\n%s\n!= saved board\n%s", loaded, b)
+	}
+
+	if loaded.WinLength() != 3 {
+		t.Errorf("win length %d != 3", loaded.WinLength())
+	}
+
+	if loaded.CurrentMark() != "O" {
+		t.Errorf("current mark %q != O", loaded.CurrentMark())
+	}
+
+	if err := loaded.Undo(); err != nil || !loaded.IsLegalMove(2, 2) {
+		t.Error("loaded board should be able to undo the last move of the saved board")
+	}
+}
+
+func TestBoardJSONTurn(t *testing.T) {
+	b := NewBoard(3)
+	b.SetStartingMark("O")
+
+	data, _ := json.Marshal(b)
+	loaded := NewBoard(3)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loaded.CurrentMark() != "O" {
+		t.Errorf("current mark %q != starting mark O", loaded.CurrentMark())
+	}
+
+	// Without a turn, it passes from the last move of the history.
+	data = []byte(`{"size": 3, "win_length": 3, "grid": [["X", "", ""], ["", "", ""], ["", "", ""]],
+		"history": [{"i": 0, "j": 0, "mark": "X"}]}`)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loaded.CurrentMark() != "O" {
+		t.Errorf("current mark %q != O", loaded.CurrentMark())
+	}
+}
+
+func TestBoardJSONBlocks(t *testing.T) {
+	b, _ := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 1}})
+	b.PlaceMark(0, 0, "X")
+
+	data, _ := json.Marshal(b)
+	loaded := NewBoard(3)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !loaded.Equal(b) || !loaded.IsBlocked(1, 1) {
+		t.Errorf("loaded board\n%s\n!= saved board\n%s", loaded, b)
+	}
+}
+
+func TestBoardJSONVersion(t *testing.T) {
+	t.Run("Current", func(t *testing.T) {
+		data, _ := json.Marshal(NewBoard(3))
+		if !strings.Contains(string(data), `"version":1`) {
+			t.Errorf("board JSON %s should carry version 1", data)
+		}
+
+		doc := `{"version": 1, "size": 3, "win_length": 3,
+			"grid": [["X", "", ""], ["", "O", ""], ["", "", ""]],
+			"history": [{"i": 0, "j": 0, "mark": "X"}, {"i": 1, "j": 1, "mark": "O"}]}`
+		b := NewBoard(3)
+		if err := json.Unmarshal([]byte(doc), b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(b.History()) != 2 || b.CurrentMark() != "X" {
+			t.Errorf("loaded board with history %v and mark to move %s != 2 moves with X to move",
+				b.History(), b.CurrentMark())
+		}
+	})
+
+	t.Run("Future", func(t *testing.T) {
+		doc := `{"version": 99, "size": 3, "win_length": 3,
+			"grid": [["", "", ""], ["", "", ""], ["", "", ""]]}`
+		err := json.Unmarshal([]byte(doc), NewBoard(3))
+		if err == nil || !strings.Contains(err.Error(), "version 99") {
+			t.Errorf("error %v should reject version 99", err)
+		}
+	})
+}
+
+func TestBoardJSONInvalid(t *testing.T) {
+	testCases := []string{
+		`{"size": 3, "win_length": 4, "grid": [["", "", ""], ["", "", ""], ["", "", ""]]}`,
+		`{"size": 3, "win_length": 3, "grid": [["", "", ""], ["", "", ""]]}`,
+		`{"size": 3, "win_length": 3, "grid": [["", "", ""], ["", ""], ["", "", ""]]}`,
+		`{"size": 3, "win_length": 3, "grid": [["X", "", ""], ["", "", ""], ["", "", ""]],
+			"history": [{"i": 1, "j": 1, "mark": "X"}]}`,
+		`{"size": 3, "win_length": 3, "grid": [["X", "", ""], ["", "", ""], ["", "", ""]],
+			"history": [{"i": 0, "j": 0, "mark": "O"}]}`,
+	}
+
+	for _, data := range testCases {
+		if err := json.Unmarshal([]byte(data), NewBoard(3)); err == nil {
+			t.Errorf("expected error when unmarshaling %s", data)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package ttt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BoardFromString parses a board from its string representation, one row per line. Cells are
+// separated by pipes as in the output of Board.String, or by spaces, or they are single characters
+// with no separator at all, e.g. "X.O". Empty positions are blank, "." or "_", and blocked
+// positions are "#", see NewBoardWithBlocks. Lines of dashes and blank lines are skipped. The size
+// of the board is the number of rows, and a player needs to fill a full row, column or diagonal to
+// win. If the board holds marks other than X and O, the marks are set in the order they first
+// appear. The marks are placed without a history since the order they were played in is unknown,
+// and the mark that has placed the fewest marks moves next. It returns an error carrying the line
+// number if a row does not have as many cells as there are rows or a cell holds a character that
+// cannot be part of a mark, such as a dash.
+func BoardFromString(s string) (*Board, error) {
+	rows := [][]string{}
+	lineNumbers := []int{}
+	for k, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(strings.Trim(line, "-+")) == "" {
+			continue
+		}
+
+		row, err := parseRow(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", k+1, err)
+		}
+
+		rows = append(rows, row)
+		lineNumbers = append(lineNumbers, k+1)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("board %q has no rows", s)
+	}
+
+	b := NewBoard(len(rows))
+	seen := make(map[string]bool)
+	var marks []string
+	for i, row := range rows {
+		if len(row) != b.cols {
+			return nil, fmt.Errorf("line %d: row has %d cells, expected %d", lineNumbers[i], len(row),
+				b.cols)
+		}
+
+		for j, cell := range row {
+			if cell == "" || cell == "." || cell == empty {
+				continue
+			}
+
+			if cell == blocked {
+				b.grid[i][j] = blocked
+				b.hasBlocks = true
+				continue
+			}
+
+			b.grid[i][j] = cell
+			if !seen[cell] {
+				seen[cell] = true
+				marks = append(marks, cell)
+			}
+		}
+	}
+
+	if err := b.inferMarks(marks); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Encode returns a compact representation of the board on a single line, e.g. "X.O|..X|O.X" for a
+// 3 by 3 board, which suits log lines better than JSON. Rows are separated by pipes, and every
+// position is a single character: its mark, "." if it is empty or "#" if it is blocked. DecodeBoard
+// parses it back as long as every mark is a single character. The history and the win length are
+// not part of it.
+func (b *Board) Encode() string {
+	rows := make([]string, b.rows)
+	for i := range b.grid {
+		var row strings.Builder
+		for _, cell := range b.grid[i] {
+			if cell == empty {
+				cell = "."
+			}
+			row.WriteString(cell)
+		}
+		rows[i] = row.String()
+	}
+
+	return strings.Join(rows, "|")
+}
+
+// DecodeBoard parses a board from the representation of Board.Encode. The board has as many rows
+// as there are rows in s and as many columns as there are positions in the first row, and a player
+// needs to fill a line as long as the shorter side to win. The marks and the mark to move are
+// inferred as in BoardFromString. It returns an error if a row does not have as many positions as
+// the first row or a position holds a character that cannot be a mark.
+func DecodeBoard(s string) (*Board, error) {
+	if s == "" {
+		return nil, errors.New("encoded board is empty")
+	}
+
+	rows := strings.Split(s, "|")
+	cols := utf8.RuneCountInString(rows[0])
+	b, err := NewRectBoard(len(rows), cols, min(len(rows), cols))
+	if err != nil {
+		return nil, fmt.Errorf("board %q: %w", s, err)
+	}
+
+	seen := make(map[string]bool)
+	var marks []string
+	for i, row := range rows {
+		cells := strings.Split(row, "")
+		if len(cells) != b.cols {
+			return nil, fmt.Errorf("row %d of %q has %d positions, expected %d", i+1, s,
+				len(cells), b.cols)
+		}
+
+		for j, cell := range cells {
+			r, _ := utf8.DecodeRuneInString(cell)
+			switch {
+			case cell == "." || cell == empty:
+				continue
+			case cell == blocked:
+				b.grid[i][j] = blocked
+				b.hasBlocks = true
+				continue
+			case !unicode.IsGraphic(r) || unicode.IsSpace(r):
+				return nil, fmt.Errorf("row %d of %q: invalid character %q", i+1, s, r)
+			}
+
+			b.grid[i][j] = cell
+			if !seen[cell] {
+				seen[cell] = true
+				marks = append(marks, cell)
+			}
+		}
+	}
+
+	if err := b.inferMarks(marks); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// inferMarks sets up the marks of a board whose marks were placed without a history. The marks
+// are set to marks, in order, unless they are only the default marks X and O. A single mark is
+// paired with the default second mark, since the other player has not placed a mark yet. The mark
+// that has placed the fewest marks moves next, the earliest of them on a tie.
+func (b *Board) inferMarks(marks []string) error {
+	for _, mark := range marks {
+		if mark != "X" && mark != "O" {
+			if len(marks) == 1 {
+				marks = append(marks, b.marks[1])
+			}
+
+			if err := b.SetMarks(marks...); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, row := range b.grid {
+		for _, cell := range row {
+			counts[cell]++
+		}
+	}
+
+	for _, mark := range b.marks {
+		if counts[mark] < counts[b.turn] {
+			b.turn = mark
+		}
+	}
+
+	return nil
+}
+
+// LoadBoardFile reads a board from the file at path, in any of the formats of BoardFromString.
+func LoadBoardFile(path string) (*Board, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := BoardFromString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// parseRow splits a line into its cells. It returns an error if a cell holds a character that
+// cannot be part of a mark.
+func parseRow(line string) ([]string, error) {
+	var cells []string
+	switch {
+	case strings.Contains(line, "|"):
+		cells = strings.Split(line, "|")
+	case len(strings.Fields(line)) > 1:
+		cells = strings.Fields(line)
+	default:
+		cells = strings.Split(strings.TrimSpace(line), "")
+	}
+
+	for k := range cells {
+		cells[k] = strings.TrimSpace(cells[k])
+		for _, r := range cells[k] {
+			if !unicode.IsGraphic(r) || unicode.IsSpace(r) || r == '-' || r == '+' {
+				return nil, fmt.Errorf("column %d: invalid character %q", k+1, r)
+			}
+		}
+	}
+
+	return cells, nil
+}
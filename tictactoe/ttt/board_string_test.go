@@ -0,0 +1,251 @@
+package ttt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBoardFromString(t *testing.T) {
+	expected := boardOf(
+		[]string{"X", "_", "O"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "_"},
+	)
+
+	for _, s := range []string{"X.O\n.X.\nO..", "X . O\n. X .\nO . .\n", expected.String()} {
+		b, err := BoardFromString(s)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", s, err)
+			continue
+		}
+
+		if !b.Equal(expected) {
+			t.Errorf("board\n%s\n!= expected board\n%s\nfor %q", b, expected, s)
+		}
+	}
+
+	for _, s := range []string{"", "X.O\n.X\nO..", "X.O\n.X.\nO..\n..."} {
+		if _, err := BoardFromString(s); err == nil {
+			t.Errorf("parsing %q should return an error", s)
+		}
+	}
+}
+
+func TestBoardFromStringRoundTrip(t *testing.T) {
+	b := NewBoard(4)
+	b.SetMarks("A", "BB")
+	b.PlaceMark(0, 0, "A")
+	b.PlaceMark(1, 2, "BB")
+	b.PlaceMark(3, 3, "A")
+
+	parsed, err := BoardFromString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !parsed.Equal(b) {
+		t.Errorf("parsed board\n%s\n!= board\n%s", parsed, b)
+	}
+
+	if marks := parsed.Marks(); len(marks) != 2 || marks[0] != "A" || marks[1] != "BB" {
+		t.Errorf("marks %v != [A BB]", marks)
+	}
+
+	// The second mark is unknown until it is placed, so only the positions can round-trip.
+	single := NewBoard(3)
+	single.SetMarks("A", "B")
+	single.PlaceMark(1, 1, "A")
+
+	parsed, err = BoardFromString(single.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if parsed.String() != single.String() || parsed.CurrentMark() != "O" {
+		t.Errorf("parsed board\n%s\nwith %s to move != board\n%s\nwith O to move", parsed,
+			parsed.CurrentMark(), single)
+	}
+}
+
+func TestBoardFromStringRoundTripBlocks(t *testing.T) {
+	b, _ := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 1}})
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(2, 2, "O")
+
+	parsed, err := BoardFromString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !parsed.Equal(b) || !parsed.IsBlocked(1, 1) {
+		t.Errorf("parsed board\n%s\n!= board\n%s", parsed, b)
+	}
+
+	if moves := parsed.GetAvailablePos(); len(moves) != 6 {
+		t.Errorf("available positions %v should leave out the block", moves)
+	}
+}
+
+func TestBoardFromStringTurn(t *testing.T) {
+	testCases := map[string]string{
+		"...\n...\n...": "X",
+		"X..\n...\n...": "O",
+		"X..\n.O.\n...": "X",
+		"...\n.O.\n...": "X",
+	}
+
+	for s, expected := range testCases {
+		b, err := BoardFromString(s)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", s, err)
+		}
+
+		if b.CurrentMark() != expected {
+			t.Errorf("current mark %q != %q for %q", b.CurrentMark(), expected, s)
+		}
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	b, _ := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 0}})
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(0, 2, "O")
+	b.PlaceMark(2, 2, "X")
+
+	s := b.Encode()
+	if s != "X.O|#..|..X" {
+		t.Errorf("encoded board %q != %q", s, "X.O|#..|..X")
+	}
+
+	decoded, err := DecodeBoard(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !decoded.Equal(b) || !decoded.IsBlocked(1, 0) {
+		t.Errorf("decoded board\n%s\n!= board\n%s", decoded, b)
+	}
+
+	if encoded := decoded.Encode(); encoded != s {
+		t.Errorf("encoded decoded board %q != %q", encoded, s)
+	}
+}
+
+func TestEncodeRoundTripCustomMark(t *testing.T) {
+	b := NewBoard(3)
+	b.SetMarks("A", "B")
+	b.PlaceMark(1, 1, "A")
+
+	s := b.Encode()
+	decoded, err := DecodeBoard(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if encoded := decoded.Encode(); encoded != s {
+		t.Errorf("encoded decoded board %q != %q", encoded, s)
+	}
+
+	if mark, _ := decoded.MarkAt(1, 1); mark != "A" {
+		t.Errorf("mark %q at (1, 1) != A", mark)
+	}
+}
+
+func TestEncodeRoundTripRectangular(t *testing.T) {
+	b, _ := NewRectBoard(3, 5, 3)
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(2, 4, "O")
+
+	s := b.Encode()
+	if s != "X....|.....|....O" {
+		t.Errorf("encoded board %q != %q", s, "X....|.....|....O")
+	}
+
+	decoded, err := DecodeBoard(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !decoded.Equal(b) {
+		t.Errorf("decoded board\n%s\n!= board\n%s", decoded, b)
+	}
+
+	if decoded, err := DecodeBoard("X.O|..X"); err != nil || decoded.Rows() != 2 ||
+		decoded.Cols() != 3 {
+		t.Errorf("decoding %q should give a 2 by 3 board, got %v, %v", "X.O|..X", decoded, err)
+	}
+}
+
+func TestDecodeBoardMalformed(t *testing.T) {
+	testCases := map[string]string{
+		"":            "empty",
+		"X.O|.X|O..":  "row 2 of \"X.O|.X|O..\" has 2 positions, expected 3",
+		"|X.O":        "board \"|X.O\"",
+		"X.O|. .|O..": "row 2 of \"X.O|. .|O..\": invalid character ' '",
+	}
+
+	for s, expected := range testCases {
+		_, err := DecodeBoard(s)
+		if err == nil {
+			t.Errorf("decoding %q should return an error", s)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("error %q should contain %q", err, expected)
+		}
+	}
+}
+
+func TestLoadBoardFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "board.txt")
+	if err := os.WriteFile(path, []byte("X.O\n.X.\nO..\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := LoadBoardFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := boardOf(
+		[]string{"X", "_", "O"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "_"},
+	)
+	if !b.Equal(expected) {
+		t.Errorf("loaded board\n%s\n!= expected board\n%s", b, expected)
+	}
+
+	if _, err := LoadBoardFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("loading a missing file should return an error")
+	}
+}
+
+func TestLoadBoardFileMalformed(t *testing.T) {
+	testCases := map[string]string{
+		"X.O\n.X.\n\nO.\n": "line 4",
+		"X | O |  \n---+---+---\n  | X |-O\n---+---+---\n  |   |  ": "line 3",
+	}
+
+	dir := t.TempDir()
+	for content, expected := range testCases {
+		path := filepath.Join(dir, "board.txt")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := LoadBoardFile(path)
+		if err == nil {
+			t.Errorf("loading %q should return an error", content)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), expected) || !strings.Contains(err.Error(), path) {
+			t.Errorf("error %q should contain the path and %q", err, expected)
+		}
+	}
+}
@@ -0,0 +1,1430 @@
+package ttt
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// boardOf builds a board from a grid of marks, using "_" for empty positions.
+func boardOf(rows ...[]string) *Board {
+	b := NewBoard(len(rows))
+	for i := range rows {
+		for j, mark := range rows[i] {
+			if mark != empty {
+				b.PlaceMark(i, j, mark)
+			}
+		}
+	}
+
+	return b
+}
+
+func TestNewBoard(t *testing.T) {
+	b := NewBoard(4)
+	if b.Size() != 4 {
+		t.Errorf("board size %d != 4", b.Size())
+	}
+
+	if n := len(b.GetAvailablePos()); n != 16 {
+		t.Errorf("available positions %d != 16", n)
+	}
+
+	if b.IsOver() {
+		t.Error("empty board should not be over")
+	}
+}
+
+func TestPlaceMark(t *testing.T) {
+	b := NewBoard(5)
+	if err := b.PlaceMark(4, 4, "X"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := b.PlaceMark(4, 4, "O"); err == nil {
+		t.Error("expected error when placing on occupied position")
+	}
+
+	for _, pos := range [][2]int{{5, 0}, {0, 5}, {-1, 0}, {0, -1}} {
+		if err := b.PlaceMark(pos[0], pos[1], "X"); err == nil {
+			t.Errorf("expected error when placing on (%d, %d)", pos[0], pos[1])
+		}
+	}
+}
+
+func TestWinnerOnLargeBoard(t *testing.T) {
+	b := NewBoard(5)
+	for i := 0; i < 4; i++ {
+		b.PlaceMark(i, 4-i, "O")
+	}
+
+	if w := b.Winner(); w != "" {
+		t.Errorf("winner %q != no winner", w)
+	}
+
+	b.PlaceMark(4, 0, "O")
+	if w := b.Winner(); w != "O" {
+		t.Errorf("winner %q != O", w)
+	}
+
+	if !b.IsOver() {
+		t.Error("board with a winner should be over")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	b := NewBoard(4)
+	b.PlaceMark(0, 0, "X")
+
+	c := b.Copy()
+	c.PlaceMark(3, 3, "O")
+
+	if c.Size() != 4 || c.grid[0][0] != "X" {
+		t.Error("copy should keep the size and marks of the original board")
+	}
+
+	if b.grid[3][3] != empty {
+		t.Error("placing a mark on the copy should not modify the original board")
+	}
+}
+
+func TestCopyConfiguration(t *testing.T) {
+	b, _ := NewRectBoard(4, 5, 3)
+	b.SetMarks("A", "B", "C")
+	b.SetStartingMark("B")
+	b.ApplyMoves([]Move{{I: 0, J: 0}, {I: 1, J: 1}, {I: 2, J: 2}, {I: 3, J: 4}})
+
+	c := b.Copy()
+	if !c.Equal(b) || !reflect.DeepEqual(c, b) {
+		t.Fatalf("copy %+v != original board %+v", c, b)
+	}
+
+	c.PlaceMark(3, 0, c.CurrentMark())
+	c.SetWinLength(4)
+	c.marks[0] = "D"
+	c.history[0].Mark = "D"
+
+	if len(b.History()) != 4 || b.History()[0].Mark != "B" || b.grid[3][0] != empty {
+		t.Errorf("history %v or grid of the original board changed with the copy", b.History())
+	}
+
+	if b.Marks()[0] != "A" || b.WinLength() != 3 || b.CurrentMark() != "C" {
+		t.Errorf("marks %v, win length %d or mark to move %s of the original board changed with the "+
+			"copy", b.Marks(), b.WinLength(), b.CurrentMark())
+	}
+}
+
+func TestMarkAt(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(1, 2, "O")
+
+	if mark, err := b.MarkAt(1, 2); err != nil || mark != "O" {
+		t.Errorf("mark at (1, 2) is (%q, %v) != O", mark, err)
+	}
+
+	if mark, err := b.MarkAt(0, 0); err != nil || mark != "" {
+		t.Errorf("mark at (0, 0) is (%q, %v) != empty", mark, err)
+	}
+
+	for _, pos := range [][2]int{{3, 0}, {0, -1}} {
+		if _, err := b.MarkAt(pos[0], pos[1]); err == nil {
+			t.Errorf("expected error for position %v outside of the board", pos)
+		}
+	}
+}
+
+func TestForEachCell(t *testing.T) {
+	b, _ := NewRectBoard(2, 3, 2)
+	b.PlaceMark(0, 1, "X")
+	b.PlaceMark(1, 0, "O")
+
+	expected := []Move{
+		{I: 0, J: 0, Mark: ""}, {I: 0, J: 1, Mark: "X"}, {I: 0, J: 2, Mark: ""},
+		{I: 1, J: 0, Mark: "O"}, {I: 1, J: 1, Mark: ""}, {I: 1, J: 2, Mark: ""},
+	}
+
+	cells := []Move{}
+	b.ForEachCell(func(i, j int, mark string) {
+		cells = append(cells, Move{I: i, J: j, Mark: mark})
+	})
+
+	if len(cells) != len(expected) {
+		t.Fatalf("visited cells %v != %v", cells, expected)
+	}
+
+	for k := range expected {
+		if cells[k] != expected[k] {
+			t.Errorf("visited cells %v != %v", cells, expected)
+			break
+		}
+	}
+}
+
+func TestCopyInto(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(1, 1, "X")
+	b.PlaceMark(0, 2, "O")
+
+	// The destination is reused even though it has a different size and moves of its own.
+	dst := NewBoard(4)
+	dst.PlaceMark(3, 3, "X")
+	b.CopyInto(dst)
+
+	if !dst.Equal(b) {
+		t.Errorf("copied board\n%s\n!= original board\n%s", dst, b)
+	}
+
+	if len(dst.History()) != 2 || dst.History()[1] != (Move{I: 0, J: 2, Mark: "O"}) {
+		t.Errorf("history %v != %v", dst.History(), b.History())
+	}
+
+	dst.PlaceMark(2, 2, "X")
+	if b.grid[2][2] != empty || len(b.History()) != 2 {
+		t.Error("placing a mark on the copy should not modify the original board")
+	}
+
+	b.CopyInto(dst)
+	if !dst.Equal(b) {
+		t.Errorf("copied board\n%s\n!= original board\n%s", dst, b)
+	}
+}
+
+func BenchmarkCopy(b *testing.B) {
+	board := midGameBoard()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		board.Copy()
+	}
+}
+
+func BenchmarkCopyInto(b *testing.B) {
+	board, dst := midGameBoard(), NewBoard(3)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		board.CopyInto(dst)
+	}
+}
+
+func TestNewBoardWithWin(t *testing.T) {
+	if _, err := NewBoardWithWin(3, 4); err == nil {
+		t.Error("expected error when win length is greater than board size")
+	}
+
+	if _, err := NewBoardWithWin(3, 0); err == nil {
+		t.Error("expected error when win length is not positive")
+	}
+
+	b, err := NewBoardWithWin(6, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b.Size() != 6 || b.WinLength() != 4 {
+		t.Errorf("board size %d and win length %d != 6 and 4", b.Size(), b.WinLength())
+	}
+}
+
+func TestSetWinLength(t *testing.T) {
+	b, _ := NewBoardWithWin(5, 4)
+	for j := 0; j < 3; j++ {
+		b.PlaceMark(2, j, "X")
+	}
+
+	if w := b.Winner(); w != "" {
+		t.Errorf("winner %q != no winner", w)
+	}
+
+	if err := b.SetWinLength(3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if w := b.Winner(); w != "X" || b.WinLength() != 3 {
+		t.Errorf("winner %q with win length %d != X with win length 3", w, b.WinLength())
+	}
+
+	for _, n := range []int{0, 6} {
+		if err := b.SetWinLength(n); err == nil {
+			t.Errorf("expected error when setting win length %d on a 5 by 5 board", n)
+		}
+	}
+
+	if b.WinLength() != 3 {
+		t.Errorf("win length %d != 3 after rejected changes", b.WinLength())
+	}
+
+	// The constructor and SetWinLength accept the same win lengths.
+	rect, _ := NewRectBoard(3, 5, 3)
+	for n := 1; n <= 5; n++ {
+		_, errNew := NewRectBoard(3, 5, n)
+		errSet := rect.SetWinLength(n)
+		if (errNew == nil) != (errSet == nil) || (errSet == nil) != (n <= 3) {
+			t.Errorf("win length %d on a 3 by 5 board: constructor error %v, SetWinLength error %v",
+				n, errNew, errSet)
+		}
+	}
+
+	if err := rect.SetWinLength(2); err != nil || rect.WinLength() != 2 {
+		t.Errorf("win length %d with error %v != 2 on a 3 by 5 board", rect.WinLength(), err)
+	}
+}
+
+func TestNewBoardWithBlocks(t *testing.T) {
+	if _, err := NewBoardWithBlocks(3, []Position{{Row: 3, Col: 0}}); err == nil {
+		t.Error("expected error when a block is outside of the board")
+	}
+
+	b, err := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, pos := range b.GetAvailablePosOrdered() {
+		if pos == (Position{Row: 1, Col: 1}) {
+			t.Errorf("blocked center should not be available")
+		}
+	}
+
+	if err := b.PlaceMark(1, 1, "X"); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("error %v != %v when placing a mark on the block", err, ErrIllegalMove)
+	}
+
+	// Only the outer rows and columns avoid the center.
+	if n := len(b.lines()); n != 4 {
+		t.Errorf("actual %d lines != expected 4 lines", n)
+	}
+
+	b.ApplyMoves([]Move{{I: 1, J: 0, Mark: "X"}, {I: 0, J: 1, Mark: "O"}, {I: 1, J: 2, Mark: "X"},
+		{I: 2, J: 1, Mark: "O"}, {I: 0, J: 0, Mark: "X"}, {I: 0, J: 2, Mark: "O"},
+		{I: 2, J: 2, Mark: "X"}})
+	if w := b.Winner(); w != "" {
+		t.Errorf("winner %q != no winner through the block\n%s", w, b)
+	}
+
+	b.Reset()
+	if !b.IsBlocked(1, 1) || b.EmptyCount() != 8 {
+		t.Errorf("reset board should keep the block\n%s", b)
+	}
+
+	b.ApplyMoves([]Move{{I: 0, J: 0, Mark: "X"}, {I: 0, J: 1, Mark: "O"}, {I: 1, J: 0, Mark: "X"},
+		{I: 0, J: 2, Mark: "O"}, {I: 2, J: 0, Mark: "X"}})
+	if w := b.Winner(); w != "X" {
+		t.Errorf("winner %q != X along a line that avoids the block\n%s", w, b)
+	}
+}
+
+func TestWinnerWithWinLength(t *testing.T) {
+	t.Run("RunInTheMiddle", func(t *testing.T) {
+		b, _ := NewBoardWithWin(6, 4)
+		for j := 1; j < 4; j++ {
+			b.PlaceMark(2, j, "X")
+		}
+
+		if w := b.Winner(); w != "" {
+			t.Errorf("winner %q != no winner", w)
+		}
+
+		b.PlaceMark(2, 4, "X")
+		if w := b.Winner(); w != "X" {
+			t.Errorf("winner %q != X", w)
+		}
+	})
+
+	t.Run("RunOnDiagonal", func(t *testing.T) {
+		b, _ := NewBoardWithWin(6, 4)
+		b.PlaceMark(1, 4, "O")
+		b.PlaceMark(2, 3, "O")
+		b.PlaceMark(3, 2, "X")
+		b.PlaceMark(4, 1, "O")
+		if w := b.Winner(); w != "" {
+			t.Errorf("winner %q != no winner", w)
+		}
+
+		b, _ = NewBoardWithWin(6, 4)
+		for k := 0; k < 4; k++ {
+			b.PlaceMark(1+k, 4-k, "O")
+		}
+
+		if w := b.Winner(); w != "O" {
+			t.Errorf("winner %q != O", w)
+		}
+	})
+}
+
+func TestNewRectBoard(t *testing.T) {
+	// A run of 4 only fits along the rows of a 3 by 5 board.
+	if _, err := NewRectBoard(3, 5, 4); err == nil {
+		t.Error("expected error when win length is greater than the shorter side")
+	}
+
+	if _, err := NewRectBoard(0, 5, 3); err == nil {
+		t.Error("expected error when board has no rows")
+	}
+
+	b, err := NewRectBoard(3, 5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b.Rows() != 3 || b.Cols() != 5 || len(b.GetAvailablePos()) != 15 {
+		t.Errorf("board of %d by %d with %d available positions != 3 by 5 with 15", b.Rows(),
+			b.Cols(), len(b.GetAvailablePos()))
+	}
+
+	// 3 runs per row, 1 per column and 3 per diagonal direction.
+	if n := len(b.lines()); n != 20 {
+		t.Errorf("actual %d lines != expected 20 lines", n)
+	}
+
+	if err := b.PlaceMark(3, 0, "X"); err == nil {
+		t.Error("expected error when placing a mark below the last row")
+	}
+
+	for j := 2; j < 4; j++ {
+		b.PlaceMark(1, j, "X")
+		b.PlaceMark(2, j, "O")
+	}
+
+	if w := b.Winner(); w != "" {
+		t.Errorf("winner %q != no winner", w)
+	}
+
+	b.PlaceMark(1, 4, "X")
+	if w := b.Winner(); w != "X" {
+		t.Errorf("winner %q != X\n%s", w, b)
+	}
+
+	if c := b.Copy(); !c.Equal(b) || c.Winner() != "X" {
+		t.Errorf("copy of the board should be equal to the board\n%s", c)
+	}
+
+	// The run turns into a column of the rotated board.
+	if r := b.Rotate90(); r.Rows() != 5 || r.Cols() != 3 || r.Winner() != "X" {
+		t.Errorf("rotated board of %d by %d with winner %q != 5 by 3 with winner X", r.Rows(),
+			r.Cols(), r.Winner())
+	}
+}
+
+func TestWinningLine(t *testing.T) {
+	b := NewBoard(3)
+	if line := b.WinningLine(); len(line) != 0 {
+		t.Errorf("winning line %v != empty line", line)
+	}
+
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(1, 0, "X")
+	b.PlaceMark(2, 0, "X")
+	b.PlaceMark(0, 1, "O")
+	b.PlaceMark(1, 1, "O")
+
+	expected := [][2]int{{0, 0}, {1, 0}, {2, 0}}
+	line := b.WinningLine()
+	if len(line) != len(expected) {
+		t.Fatalf("winning line %v != %v", line, expected)
+	}
+
+	for k := range expected {
+		if line[k] != expected[k] {
+			t.Errorf("winning line %v != %v", line, expected)
+		}
+	}
+
+	if w := b.Winner(); w != b.grid[line[0][0]][line[0][1]] {
+		t.Errorf("winner %q is not the mark on the winning line", w)
+	}
+}
+
+func TestString(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(1, 1, "O")
+	b.PlaceMark(2, 1, "X")
+
+	expected := " X |   |   \n" +
+		"---+---+---\n" +
+		"   | O |   \n" +
+		"---+---+---\n" +
+		"   | X |   "
+	if b.String() != expected {
+		t.Errorf("board string\n%s\n!= expected\n%s", b.String(), expected)
+	}
+
+	b = NewBoard(2)
+	b.SetMarks("XX", "O")
+	b.PlaceMark(0, 1, "XX")
+
+	expected = "    | XX \n" +
+		"----+----\n" +
+		"    |    "
+	if b.String() != expected {
+		t.Errorf("board string\n%s\n!= expected\n%s", b.String(), expected)
+	}
+}
+
+func TestIsLegalMove(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(1, 1, "X")
+
+	testCases := []struct {
+		i, j     int
+		expected bool
+	}{
+		{0, 0, true},
+		{2, 2, true},
+		{1, 1, false},
+		{3, 0, false},
+		{0, 3, false},
+		{-1, 1, false},
+	}
+
+	for _, tc := range testCases {
+		if legal := b.IsLegalMove(tc.i, tc.j); legal != tc.expected {
+			t.Errorf("IsLegalMove(%d, %d) %v != %v", tc.i, tc.j, legal, tc.expected)
+		}
+	}
+}
+
+func TestIsDraw(t *testing.T) {
+	t.Run("FullBoardWithoutWinner", func(t *testing.T) {
+		b := boardOf(
+			[]string{"X", "O", "X"},
+			[]string{"X", "O", "O"},
+			[]string{"O", "X", "X"},
+		)
+
+		if !b.IsDraw() {
+			t.Error("full board without a winner should be a draw")
+		}
+
+		if w := b.Winner(); w != "" {
+			t.Errorf("winner %q != no winner", w)
+		}
+	})
+
+	t.Run("FullBoardWithWinner", func(t *testing.T) {
+		b := boardOf(
+			[]string{"X", "O", "X"},
+			[]string{"O", "X", "O"},
+			[]string{"O", "X", "X"},
+		)
+
+		if b.IsDraw() {
+			t.Error("full board with a winner should not be a draw")
+		}
+
+		if w := b.Winner(); w != "X" {
+			t.Errorf("winner %q != X", w)
+		}
+	})
+
+	t.Run("BoardNotFull", func(t *testing.T) {
+		if NewBoard(3).IsDraw() {
+			t.Error("empty board should not be a draw")
+		}
+	})
+}
+
+func TestPlaceMarkIllegalMoveError(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(0, 2, "X")
+
+	err := b.PlaceMark(0, 2, "O")
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Fatalf("error %v is not ErrIllegalMove", err)
+	}
+
+	var illegal *IllegalMoveError
+	if !errors.As(err, &illegal) {
+		t.Fatalf("error %v is not an IllegalMoveError", err)
+	}
+
+	if illegal.I != 0 || illegal.J != 2 {
+		t.Errorf("illegal move (%d, %d) != (0, 2)", illegal.I, illegal.J)
+	}
+}
+
+func TestKey(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(1, 1, "O")
+
+	c := NewBoard(3)
+	c.PlaceMark(1, 1, "O")
+	c.PlaceMark(0, 0, "X")
+
+	if b.Key() != c.Key() {
+		t.Errorf("key %q != key %q for the same marks", b.Key(), c.Key())
+	}
+
+	c.PlaceMark(2, 2, "X")
+	if b.Key() == c.Key() {
+		t.Errorf("key %q should differ for different marks", b.Key())
+	}
+}
+
+func TestCanonicalForm(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(0, 1, "O")
+	form := b.CanonicalForm()
+
+	// Every rotation and reflection of the board above.
+	symmetric := [][2][2]int{
+		{{0, 2}, {1, 2}},
+		{{2, 2}, {2, 1}},
+		{{2, 0}, {1, 0}},
+		{{0, 2}, {0, 1}},
+		{{0, 0}, {1, 0}},
+		{{2, 0}, {2, 1}},
+		{{2, 2}, {1, 2}},
+	}
+
+	for _, pos := range symmetric {
+		c := NewBoard(3)
+		c.PlaceMark(pos[0][0], pos[0][1], "X")
+		c.PlaceMark(pos[1][0], pos[1][1], "O")
+		if c.CanonicalForm() != form {
+			t.Errorf("canonical form %q != %q on symmetric board\n%s", c.CanonicalForm(), form, c)
+		}
+	}
+
+	c := NewBoard(3)
+	c.PlaceMark(0, 0, "X")
+	c.PlaceMark(1, 1, "O")
+	if c.CanonicalForm() == form {
+		t.Errorf("canonical form %q should differ on asymmetric board\n%s", form, c)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	b := NewBoard(3)
+	if err := b.Undo(); err == nil {
+		t.Error("expected error when there is no move to undo")
+	}
+
+	b.PlaceMark(1, 1, "X")
+	b.PlaceMark(0, 2, "O")
+	if err := b.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	availPos := b.GetAvailablePos()
+	if len(availPos) != 8 {
+		t.Fatalf("available positions %v should have 8 positions", availPos)
+	}
+
+	reverted := false
+	for _, pos := range availPos {
+		if pos == (Position{Row: 1, Col: 1}) {
+			t.Error("(1, 1) should still be occupied")
+		}
+		if pos == (Position{Row: 0, Col: 2}) {
+			reverted = true
+		}
+	}
+
+	if !reverted {
+		t.Error("(0, 2) should be available after undo")
+	}
+
+	c := b.Copy()
+	if err := c.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if c.IsLegalMove(1, 1) == b.IsLegalMove(1, 1) {
+		t.Error("undo on the copy should not modify the original board")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(0, 0, "X")
+
+	c := NewBoard(3)
+	c.PlaceMark(0, 0, "X")
+	if !b.Equal(c) {
+		t.Error("boards with the same marks should be equal")
+	}
+
+	c.PlaceMark(1, 1, "O")
+	if b.Equal(c) {
+		t.Error("boards with different marks should not be equal")
+	}
+
+	if NewBoard(3).Equal(NewBoard(4)) {
+		t.Error("boards of different sizes should not be equal")
+	}
+
+	t.Run("DifferentMoveOrders", func(t *testing.T) {
+		b, c := NewBoard(3), NewBoard(3)
+		for _, m := range []Move{{1, 1, "X"}, {0, 0, "O"}, {2, 2, "X"}, {0, 2, "O"}} {
+			b.PlaceMark(m.I, m.J, m.Mark)
+		}
+		for _, m := range []Move{{2, 2, "X"}, {0, 2, "O"}, {1, 1, "X"}, {0, 0, "O"}} {
+			c.PlaceMark(m.I, m.J, m.Mark)
+		}
+
+		if !b.Equal(c) {
+			t.Errorf("board\n%s\nshould equal the board reached by another move order\n%s", b, c)
+		}
+	})
+
+	t.Run("SingleCell", func(t *testing.T) {
+		b := boardOf(
+			[]string{"X", "O", "_"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "_"},
+		)
+		c := boardOf(
+			[]string{"X", "_", "O"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "_"},
+		)
+
+		if b.Equal(c) {
+			t.Errorf("board\n%s\nshould not equal\n%s", b, c)
+		}
+	})
+
+	t.Run("SideToMove", func(t *testing.T) {
+		b, c := NewBoard(3), NewBoard(3)
+		if err := c.SetStartingMark("O"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if b.Equal(c) {
+			t.Error("boards with different marks to move should not be equal")
+		}
+	})
+}
+
+func TestHistory(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{1, 1}, {0, 0}, {2, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 2}, {2, 0}}}
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}))
+	if _, err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Move{
+		{I: 1, J: 1, Mark: "X"},
+		{I: 0, J: 2, Mark: "O"},
+		{I: 0, J: 0, Mark: "X"},
+		{I: 2, J: 0, Mark: "O"},
+		{I: 2, J: 2, Mark: "X"},
+	}
+
+	history := g.board.History()
+	if len(history) != len(expected) {
+		t.Fatalf("history %v != %v", history, expected)
+	}
+
+	for k := range expected {
+		if history[k] != expected[k] {
+			t.Errorf("history %v != %v", history, expected)
+		}
+	}
+
+	history[0].Mark = "O"
+	if g.board.History()[0].Mark != "X" {
+		t.Error("modifying the returned history should not modify the board")
+	}
+
+	c := g.board.Copy()
+	if len(c.History()) != len(expected) {
+		t.Errorf("copy history %v != %v", c.History(), expected)
+	}
+}
+
+func TestSetMarks(t *testing.T) {
+	b := NewBoard(3)
+	if b.Opponent("X") != "O" || b.Opponent("O") != "X" {
+		t.Errorf("opponents of X and O should be O and X, got %q and %q", b.Opponent("X"),
+			b.Opponent("O"))
+	}
+
+	if err := b.SetMarks("A", "B"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b.Opponent("A") != "B" || b.Opponent("B") != "A" {
+		t.Errorf("opponents of A and B should be B and A, got %q and %q", b.Opponent("A"),
+			b.Opponent("B"))
+	}
+
+	if b.Opponent("X") != "" {
+		t.Errorf("opponent of X %q != empty string", b.Opponent("X"))
+	}
+
+	if c := b.Copy(); c.Opponent("A") != "B" {
+		t.Error("copy should keep the marks of the board")
+	}
+
+	for _, marks := range [][]string{{"A"}, {"A", "A"}, {"A", ""}, {"_", "B"}} {
+		if err := b.SetMarks(marks...); err == nil {
+			t.Errorf("expected error when setting marks %v", marks)
+		}
+	}
+}
+
+func TestWinningMoves(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "_", "X"},
+		[]string{"_", "O", "_"},
+		[]string{"X", "O", "_"},
+	)
+
+	expected := []Position{{0, 1}, {1, 0}}
+	moves := b.WinningMoves("X")
+	if len(moves) != len(expected) {
+		t.Fatalf("winning moves %v != %v", moves, expected)
+	}
+
+	for k := range expected {
+		if moves[k] != expected[k] {
+			t.Errorf("winning moves %v != %v", moves, expected)
+		}
+	}
+
+	if moves := b.WinningMoves("O"); len(moves) != 1 || moves[0] != (Position{Row: 0, Col: 1}) {
+		t.Errorf("winning moves %v != [{0 1}]", moves)
+	}
+
+	if moves := NewBoard(3).WinningMoves("X"); len(moves) != 0 {
+		t.Errorf("winning moves %v should be empty on an empty board", moves)
+	}
+}
+
+func TestIsWinningMove(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"X", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	if !b.IsWinningMove(2, 0, "X") {
+		t.Errorf("(2, 0) should complete the left column of X\n%s", b)
+	}
+
+	if b.IsWinningMove(2, 2, "X") {
+		t.Errorf("(2, 2) should not win for X\n%s", b)
+	}
+
+	if b.IsWinningMove(0, 0, "X") || b.IsWinningMove(2, 0, "Z") {
+		t.Error("an illegal move should not win")
+	}
+
+	if b.grid[2][0] != empty || len(b.History()) != 4 {
+		t.Error("checking a move should not change the board")
+	}
+
+	// A longer board needs the full win length.
+	c, _ := NewBoardWithWin(5, 4)
+	c.ApplyMoves([]Move{{I: 1, J: 1}, {I: 0, J: 4}, {I: 2, J: 2}, {I: 1, J: 4}})
+	if c.IsWinningMove(3, 3, "X") {
+		t.Errorf("(3, 3) should not win with three in a row\n%s", c)
+	}
+
+	c.ApplyMoves([]Move{{I: 3, J: 3}, {I: 2, J: 4}})
+	if !c.IsWinningMove(0, 0, "X") || !c.IsWinningMove(4, 4, "X") {
+		t.Errorf("both ends of the diagonal should win for X\n%s", c)
+	}
+}
+
+func TestBlockingMoves(t *testing.T) {
+	b := boardOf(
+		[]string{"O", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	if moves := b.BlockingMoves("X"); len(moves) != 1 || moves[0] != (Position{Row: 0, Col: 2}) {
+		t.Errorf("blocking moves %v != [{0 2}]", moves)
+	}
+
+	// O already blocks the diagonal of X.
+	if moves := b.BlockingMoves("O"); len(moves) != 0 {
+		t.Errorf("blocking moves %v != []", moves)
+	}
+
+	if moves := b.BlockingMoves("Z"); len(moves) != 0 {
+		t.Errorf("blocking moves %v should be empty for a mark not in the game", moves)
+	}
+}
+
+func TestCreatesFork(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "_", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"_", "_", "X"},
+	)
+	b.PlaceMark(0, 1, "O")
+
+	// X threatens the left column and the bottom row at once.
+	if !b.CreatesFork(2, 0, "X") {
+		t.Errorf("(2, 0) should create a fork for X\n%s", b)
+	}
+
+	if b.CreatesFork(1, 2, "X") {
+		t.Errorf("(1, 2) should not create a fork for X\n%s", b)
+	}
+
+	if b.CreatesFork(0, 0, "X") {
+		t.Error("an occupied position should not create a fork")
+	}
+}
+
+func TestPossibleWinners(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "X"},
+		[]string{"X", "O", "O"},
+		[]string{"O", "X", "_"},
+	)
+	if winners := b.PossibleWinners(); len(winners) != 0 {
+		t.Errorf("possible winners %v should be empty when every line is blocked\n%s", winners, b)
+	}
+
+	b = boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"X", "X", "O"},
+	)
+	// X can only complete the left column, and O the middle row or the right column.
+	if winners := b.PossibleWinners(); len(winners) != 2 || winners[0] != "X" || winners[1] != "O" {
+		t.Errorf("possible winners %v != [X O]", winners)
+	}
+
+	if winners := NewBoard(3).PossibleWinners(); len(winners) != 2 {
+		t.Errorf("possible winners %v != [X O] on an empty board", winners)
+	}
+}
+
+func TestIsForcedDraw(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"_", "X", "X"},
+	)
+
+	// O has to block the bottom row, and X has to block the middle row afterwards.
+	if !b.IsForcedDraw("O") {
+		t.Errorf("board should be a forced draw with O to move\n%s", b)
+	}
+
+	// X wins right away on the bottom row.
+	if b.IsForcedDraw("X") {
+		t.Errorf("board should not be a forced draw with X to move\n%s", b)
+	}
+
+	if NewBoard(3).IsForcedDraw("X") != true {
+		t.Error("empty board should be a forced draw")
+	}
+}
+
+func TestGetAvailablePos(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "_", "O"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "X"},
+	)
+
+	expected := []Position{{Row: 0, Col: 1}, {Row: 1, Col: 0}, {Row: 1, Col: 2}, {Row: 2, Col: 1}}
+	positions := b.GetAvailablePos()
+	if len(positions) != len(expected) {
+		t.Fatalf("available positions %v != %v", positions, expected)
+	}
+
+	for k := range expected {
+		if positions[k] != expected[k] {
+			t.Errorf("available positions %v != %v", positions, expected)
+			break
+		}
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	b, err := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(0, 1, "O")
+	b.PlaceMark(2, 2, "X")
+
+	if moves := b.LegalMoves("X"); len(moves) != 0 {
+		t.Errorf("X should have no legal moves on the turn of O, got %v", moves)
+	}
+
+	expected := []Position{{Row: 0, Col: 2}, {Row: 1, Col: 0}, {Row: 1, Col: 2}, {Row: 2, Col: 0},
+		{Row: 2, Col: 1}}
+	if moves := b.LegalMoves("O"); !reflect.DeepEqual(moves, expected) {
+		t.Errorf("legal moves %v != %v", moves, expected)
+	}
+}
+
+func TestGetAvailablePosOrdered(t *testing.T) {
+	positions := NewBoard(3).GetAvailablePosOrdered()
+	expected := []Position{{1, 1}, {0, 1}, {1, 0}, {1, 2}, {2, 1}, {0, 0}, {0, 2}, {2, 0}, {2, 2}}
+	if len(positions) != len(expected) {
+		t.Fatalf("ordered positions %v != %v", positions, expected)
+	}
+
+	for k := range expected {
+		if positions[k] != expected[k] {
+			t.Errorf("ordered positions %v != %v", positions, expected)
+			break
+		}
+	}
+
+	b := boardOf(
+		[]string{"X", "_", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+	if first := b.GetAvailablePosOrdered()[0]; first != (Position{Row: 0, Col: 1}) {
+		t.Errorf("first position %v != (0, 1) with the center taken", first)
+	}
+}
+
+func TestReset(t *testing.T) {
+	b, _ := NewBoardWithWin(4, 3)
+	for k, pos := range b.GetAvailablePos() {
+		b.PlaceMark(pos.Row, pos.Col, b.Marks()[k%2])
+	}
+
+	b.Reset()
+	expected, _ := NewBoardWithWin(4, 3)
+	if !b.Equal(expected) {
+		t.Errorf("reset board\n%s\n!= new board\n%s", b, expected)
+	}
+
+	if len(b.History()) != 0 {
+		t.Errorf("history %v should be empty", b.History())
+	}
+
+	if b.Key() != expected.Key() {
+		t.Errorf("key %q != %q", b.Key(), expected.Key())
+	}
+
+	if err := b.PlaceMark(0, 0, "X"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestApplyMoves(t *testing.T) {
+	t.Run("ValidMoves", func(t *testing.T) {
+		b := NewBoard(3)
+		err := b.ApplyMoves([]Move{
+			{I: 1, J: 1},
+			{I: 0, J: 0},
+			{I: 2, J: 2, Mark: "X"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := boardOf(
+			[]string{"O", "_", "_"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "X"},
+		)
+		if !b.Equal(expected) {
+			t.Errorf("board\n%s\n!= expected board\n%s", b, expected)
+		}
+	})
+
+	t.Run("IllegalThirdMove", func(t *testing.T) {
+		b := NewBoard(3)
+		err := b.ApplyMoves([]Move{
+			{I: 1, J: 1},
+			{I: 0, J: 0},
+			{I: 1, J: 1},
+			{I: 2, J: 2},
+		})
+		if !errors.Is(err, ErrIllegalMove) {
+			t.Fatalf("error %v is not ErrIllegalMove", err)
+		}
+
+		if !strings.Contains(err.Error(), "move 2") {
+			t.Errorf("error %q should contain the index of the illegal move", err)
+		}
+
+		if n := len(b.History()); n != 2 {
+			t.Errorf("actual %d moves on the board != expected 2 moves", n)
+		}
+	})
+}
+
+func TestValidateMoves(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(1, 1, "X")
+
+	moves := []Move{
+		{I: 0, J: 0, Mark: "O"},
+		{I: 1, J: 1, Mark: "X"}, // occupied
+		{I: 3, J: 0, Mark: "X"}, // out of range
+		{I: 0, J: 1, Mark: "O"}, // wrong turn
+		{I: 0, J: 2},
+		{I: 2, J: 0, Mark: "O"},
+		{I: 2, J: 2, Mark: "Q"}, // not in the game
+	}
+	expected := []bool{true, false, false, false, true, true, false}
+
+	errs := b.ValidateMoves(moves)
+	if len(errs) != len(moves) {
+		t.Fatalf("actual %d errors != expected %d errors", len(errs), len(moves))
+	}
+
+	for k, err := range errs {
+		if legal := err == nil; legal != expected[k] {
+			t.Errorf("move %d %v: error %v, expected legal %v", k, moves[k], err, expected[k])
+		}
+
+		if err != nil && !errors.Is(err, ErrIllegalMove) {
+			t.Errorf("move %d: error %v should wrap %v", k, err, ErrIllegalMove)
+		}
+	}
+
+	if len(b.History()) != 1 {
+		t.Errorf("validating moves should not change the board\n%s", b)
+	}
+
+	// X wins along the anti-diagonal, so no move is legal after it.
+	errs = b.ValidateMoves([]Move{{I: 0, J: 0}, {I: 0, J: 2}, {I: 0, J: 1}, {I: 2, J: 0},
+		{I: 2, J: 2}})
+	if errs[3] != nil || errs[4] == nil {
+		t.Errorf("errors %v != a legal winning move followed by an illegal move", errs)
+	}
+}
+
+func TestWinnerAfter(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for game := 0; game < 500; game++ {
+		size := 3 + r.Intn(3)
+		b, _ := NewBoardWithWin(size, 3+r.Intn(size-2))
+		for !b.IsOver() {
+			available := b.GetAvailablePos()
+			pos := available[r.Intn(len(available))]
+			b.PlaceMark(pos.Row, pos.Col, b.CurrentMark())
+
+			if after, full := b.WinnerAfter(pos.Row, pos.Col), b.Winner(); after != full {
+				t.Fatalf("winner %q after (%d, %d) != winner %q\n%s", after, pos.Row, pos.Col, full,
+					b)
+			}
+		}
+	}
+
+	if w := NewBoard(3).WinnerAfter(1, 1); w != "" {
+		t.Errorf("winner %q after an empty position != no winner", w)
+	}
+}
+
+func TestPlaceMarkChecked(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "X", "_"},
+		[]string{"O", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	over, winner, err := b.PlaceMarkChecked(1, 2, "O")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !over || winner != "O" {
+		t.Errorf("over %v and winner %q != over true and winner O", over, winner)
+	}
+
+	b = NewBoard(3)
+	if over, winner, _ := b.PlaceMarkChecked(1, 1, "X"); over || winner != "" {
+		t.Errorf("over %v and winner %q != over false and no winner", over, winner)
+	}
+
+	if _, _, err := b.PlaceMarkChecked(1, 1, "O"); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("error %v is not ErrIllegalMove", err)
+	}
+}
+
+func TestPlaceMarkInvalidMark(t *testing.T) {
+	b := NewBoard(3)
+	err := b.PlaceMark(0, 0, "Q")
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Fatalf("error %v is not ErrIllegalMove", err)
+	}
+
+	if b.grid[0][0] != empty || len(b.History()) != 0 {
+		t.Errorf("board should be unchanged\n%s", b)
+	}
+
+	if err := b.PlaceMark(0, 0, "x"); err == nil {
+		t.Error("lowercase x should not be a mark of the game")
+	}
+}
+
+func TestCurrentMark(t *testing.T) {
+	b := NewBoard(3)
+	if b.CurrentMark() != "X" {
+		t.Errorf("current mark %q != X on a new board", b.CurrentMark())
+	}
+
+	for k, expected := range []string{"O", "X", "O"} {
+		b.PlaceMark(k, k, b.CurrentMark())
+		if b.CurrentMark() != expected {
+			t.Errorf("current mark %q != %q after %d moves", b.CurrentMark(), expected, k+1)
+		}
+	}
+
+	if c := b.Copy(); c.CurrentMark() != "O" {
+		t.Errorf("current mark %q of copy != O", c.CurrentMark())
+	}
+
+	b.Undo()
+	if b.CurrentMark() != "X" {
+		t.Errorf("current mark %q != X after undo", b.CurrentMark())
+	}
+
+	if err := b.SetStartingMark("Q"); err == nil {
+		t.Error("setting a mark that is not in the game should return an error")
+	}
+
+	b.Reset()
+	if err := b.SetStartingMark("O"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b.CurrentMark() != "O" {
+		t.Errorf("current mark %q != starting mark O", b.CurrentMark())
+	}
+}
+
+func TestEmptyCount(t *testing.T) {
+	b := NewBoard(3)
+	if b.EmptyCount() != 9 || b.Full() {
+		t.Errorf("empty count %d and full %v != 9 and false on an empty board", b.EmptyCount(),
+			b.Full())
+	}
+
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(1, 1, "O")
+	if b.EmptyCount() != 7 || b.Full() {
+		t.Errorf("empty count %d and full %v != 7 and false after 2 moves", b.EmptyCount(), b.Full())
+	}
+
+	b = boardOf(
+		[]string{"X", "O", "X"},
+		[]string{"X", "O", "O"},
+		[]string{"O", "X", "X"},
+	)
+	if b.EmptyCount() != 0 || !b.Full() {
+		t.Errorf("empty count %d and full %v != 0 and true on a full board", b.EmptyCount(),
+			b.Full())
+	}
+}
+
+func TestRotate90(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "_"},
+	)
+	original := b.Copy()
+
+	rotated := b.Rotate90()
+	expected := boardOf(
+		[]string{"O", "_", "X"},
+		[]string{"_", "X", "O"},
+		[]string{"_", "_", "_"},
+	)
+	if !rotated.Equal(expected) {
+		t.Errorf("rotated board\n%s\n!= expected board\n%s", rotated, expected)
+	}
+
+	if !b.Equal(original) {
+		t.Errorf("board\n%s\nshould not change after rotating", b)
+	}
+
+	for k := 1; k < 4; k++ {
+		rotated = rotated.Rotate90()
+	}
+	if !rotated.Equal(b) {
+		t.Errorf("board rotated 4 times\n%s\n!= original board\n%s", rotated, b)
+	}
+
+	// The last move is rotated as well, so undoing it clears the rotated position.
+	rotated = b.Rotate90()
+	rotated.Undo()
+	if rotated.grid[0][0] != empty {
+		t.Errorf("undo should clear the rotated last move\n%s", rotated)
+	}
+}
+
+func TestRotate90WinningLine(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "O"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	for k := 0; k < 4; k++ {
+		if b.Winner() != "X" || len(b.WinningLine()) != 3 {
+			t.Errorf("X should win after %d rotations\n%s", k, b)
+		}
+		b = b.Rotate90()
+	}
+}
+
+func TestMirrorHorizontal(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "_"},
+	)
+
+	expected := boardOf(
+		[]string{"_", "O", "X"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "O"},
+	)
+	if mirrored := b.MirrorHorizontal(); !mirrored.Equal(expected) {
+		t.Errorf("mirrored board\n%s\n!= expected board\n%s", mirrored, expected)
+	}
+
+	// The rotations of the board and of its mirror image are the 8 symmetries of the board.
+	keys := make(map[string]bool)
+	for _, start := range []*Board{b, b.MirrorHorizontal()} {
+		for k := 0; k < 4; k++ {
+			keys[start.Key()] = true
+			start = start.Rotate90()
+		}
+	}
+
+	if len(keys) != 8 {
+		t.Errorf("distinct symmetries %d != 8", len(keys))
+	}
+}
+
+func TestLastMove(t *testing.T) {
+	b := NewBoard(3)
+	if _, _, _, ok := b.LastMove(); ok {
+		t.Error("empty board should have no last move")
+	}
+
+	b.PlaceMark(1, 1, "X")
+	b.PlaceMark(0, 2, "O")
+	i, j, mark, ok := b.LastMove()
+	if !ok || i != 0 || j != 2 || mark != "O" {
+		t.Errorf("last move (%d, %d) %q %v != (0, 2) O true", i, j, mark, ok)
+	}
+
+	b.Undo()
+	if i, j, mark, _ := b.LastMove(); i != 1 || j != 1 || mark != "X" {
+		t.Errorf("last move (%d, %d) %q != (1, 1) X after undo", i, j, mark)
+	}
+}
+
+func TestOccupancyMask(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"O", "_", "X"},
+	)
+
+	if mask := b.OccupancyMask("X"); mask != 0x111 {
+		t.Errorf("actual mask %#x != expected mask %#x", mask, 0x111)
+	}
+
+	if mask := b.OccupancyMask("O"); mask != 0x42 {
+		t.Errorf("actual mask %#x != expected mask %#x", mask, 0x42)
+	}
+
+	if b.Winner() != "X" {
+		t.Errorf("actual winner %q != expected winner X", b.Winner())
+	}
+
+	if mask := NewBoard(9).OccupancyMask("X"); mask != 0 {
+		t.Errorf("mask %#x of a board larger than 8 by 8 should be 0", mask)
+	}
+
+	// Winner is on the hot path of the search.
+	if allocs := testing.AllocsPerRun(100, func() { b.Winner() }); allocs != 0 {
+		t.Errorf("Winner allocates %v times per call, expected 0", allocs)
+	}
+
+	// Winner agrees with the winning line on every position of a few random games.
+	r := rand.New(rand.NewSource(42))
+	for _, size := range []int{3, 4, 9} {
+		for n := 0; n < 20; n++ {
+			g := NewBoard(size)
+			for !g.IsOver() {
+				i, j, _ := randomMove(g, r)
+				g.PlaceMark(i, j, g.CurrentMark())
+
+				line, expected := g.WinningLine(), ""
+				if len(line) > 0 {
+					expected = g.grid[line[0][0]][line[0][1]]
+				}
+
+				if g.Winner() != expected {
+					t.Fatalf("actual winner %q != expected winner %q\n%s", g.Winner(), expected, g)
+				}
+			}
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		size      int
+		winLength int
+		expected  int
+	}{
+		{size: 3, winLength: 3, expected: 8},
+		{size: 4, winLength: 4, expected: 10},
+		{size: 4, winLength: 3, expected: 24},
+	}
+
+	for _, tc := range testCases {
+		b, _ := NewBoardWithWin(tc.size, tc.winLength)
+		if n := len(b.lines()); n != tc.expected {
+			t.Errorf("actual %d lines on a %d by %d board with win length %d != expected %d lines",
+				n, tc.size, tc.size, tc.winLength, tc.expected)
+		}
+	}
+
+	// Copies share the lines computed for the original board.
+	b := NewBoard(3)
+	if &b.lines()[0][0] != &b.Copy().lines()[0][0] {
+		t.Error("lines of a copy should be shared with the original board")
+	}
+}
+
+func TestHash(t *testing.T) {
+	b1, b2 := NewBoard(3), NewBoard(3)
+	b1.ApplyMoves([]Move{{I: 0, J: 0, Mark: "X"}, {I: 1, J: 1, Mark: "O"}, {I: 2, J: 2, Mark: "X"}})
+	b2.ApplyMoves([]Move{{I: 2, J: 2, Mark: "X"}, {I: 1, J: 1, Mark: "O"}, {I: 0, J: 0, Mark: "X"}})
+
+	if b1.Hash() != b2.Hash() {
+		t.Errorf("hash %x != hash %x of the same position reached in another order", b1.Hash(),
+			b2.Hash())
+	}
+
+	if b1.Hash() != b1.Copy().Hash() {
+		t.Errorf("hash %x of the copy != hash %x", b1.Copy().Hash(), b1.Hash())
+	}
+
+	b3 := NewBoard(3)
+	b3.ApplyMoves([]Move{{I: 0, J: 0, Mark: "X"}, {I: 1, J: 1, Mark: "O"}, {I: 2, J: 1, Mark: "X"}})
+	if b1.Hash() == b3.Hash() {
+		t.Errorf("hash %x should change when a single position differs", b1.Hash())
+	}
+
+	// The same marks with the other mark to move.
+	b4 := b1.Copy()
+	b4.SetStartingMark("X")
+	if b1.Hash() == b4.Hash() {
+		t.Errorf("hash %x should change with the mark to move", b1.Hash())
+	}
+}
@@ -1,23 +1,290 @@
 package ttt
 
-// NewComputerPlayer is a constructor for computer player.
-func NewComputerPlayer(n, m string) *ComputerPlayer {
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// Difficulty determines how well a computer player plays.
+type Difficulty int
+
+const (
+	// Easy picks a random legal move.
+	Easy Difficulty = iota
+	// Medium picks the optimal move half of the time and a random legal move otherwise.
+	Medium
+	// Hard always picks the optimal move. Of the winning moves it picks the quickest win, and when
+	// every move loses it picks the move that delays the loss the longest.
+	Hard
+)
+
+// mediumOptimalRate is the probability that a Medium computer player picks the optimal move.
+const mediumOptimalRate = 0.5
+
+// Weights are the points the heuristic evaluation of a depth limited search gives for holding a
+// center, a corner or an edge position. The center is the middle position, or the four middle
+// positions on boards of even size, and every position that is neither a center nor a corner
+// counts as an edge.
+type Weights struct {
+	Center int
+	Corner int
+	Edge   int
+}
+
+// defaultWeights prefer the center and the corners, which lie on the most lines of a 3 by 3 board.
+var defaultWeights = Weights{Center: 1, Corner: 1, Edge: 0}
+
+// NewComputerPlayer is a constructor for computer player. Its random choices are drawn from a
+// package-level source unless another one is set with SetRandom.
+func NewComputerPlayer(n, m string, d Difficulty) *ComputerPlayer {
 	return &ComputerPlayer{
-		name: n,
-		mark: m,
+		name:       n,
+		mark:       m,
+		difficulty: d,
+		memoize:    true,
+		useBook:    true,
+		weights:    defaultWeights,
 	}
 }
 
-// ComputerPlayer is an AI player that makes undefeatable moves.
+// ComputerPlayer is an AI player. On Hard difficulty it makes undefeatable moves.
 type ComputerPlayer struct {
-	name string
-	mark string
+	name       string
+	mark       string
+	difficulty Difficulty
+	maxDepth   int
+
+	// maxNodes is the number of positions a search for a move may visit, zero means no limit.
+	maxNodes int
+
+	// random is the source of every random choice of the player, the package-level source is used
+	// if it is nil.
+	random *rand.Rand
+
+	// memoize turns on the transposition table of the search, and useBook turns on the opening
+	// book which is consulted before searching.
+	memoize bool
+	useBook bool
+
+	// temperature spreads the choice of move over weaker moves, zero always picks the best move.
+	temperature float64
+
+	// weights value the positions held when a depth limited search evaluates a position.
+	weights Weights
+
+	// nodesVisited is the number of positions visited by the last search, it is reset whenever a
+	// move is requested.
+	nodesVisited int
+}
+
+// search holds the state of a single search for the next move.
+type search struct {
+	ctx      context.Context
+	maxDepth int
+
+	// cache is the transposition table which maps a board key to the value of the position for
+	// each mark to move.
+	cache        map[string]map[string]int
+	nodesVisited int
+
+	// budget is shared by the searches of the candidate moves, it is nil if there is no limit.
+	budget *nodeBudget
+}
+
+func newSearch(ctx context.Context, maxDepth int) *search {
+	return &search{
+		ctx:      ctx,
+		maxDepth: maxDepth,
+		cache:    make(map[string]map[string]int),
+	}
+}
+
+// nodeBudget is the number of positions that the searches for a move may still visit together.
+// The searches run in parallel, so it is safe for concurrent use.
+type nodeBudget struct {
+	remaining int64
 }
 
+// newNodeBudget returns a budget of n positions, or nil if n is not positive.
+func newNodeBudget(n int) *nodeBudget {
+	if n <= 0 {
+		return nil
+	}
+
+	return &nodeBudget{remaining: int64(n)}
+}
+
+// spend takes a position out of the budget and reports whether the budget allowed it. A nil
+// budget allows every position.
+func (nb *nodeBudget) spend() bool {
+	return nb == nil || atomic.AddInt64(&nb.remaining, -1) >= 0
+}
+
+// cancelCheckInterval is the number of positions searched between two checks of whether the
+// search has been cancelled.
+const cancelCheckInterval = 256
+
 // GetMove returns next move.
-func (cp *ComputerPlayer) GetMove(b *board) (int, int, error) {
-	move := cp.minimax(b, cp.Mark(), 1)
-	return move.i, move.j, nil
+func (cp *ComputerPlayer) GetMove(b *Board) (int, int, error) {
+	return cp.GetMoveContext(context.Background(), b)
+}
+
+// GetMoveContext returns next move, and it stops searching once ctx is cancelled. When the search
+// is cancelled it returns the error of ctx, along with the best move found so far. Likewise it
+// returns ErrSearchTruncated along with the best move found so far when the search runs out of
+// nodes, see SetMaxNodes. It returns an error if the game is already over.
+func (cp *ComputerPlayer) GetMoveContext(ctx context.Context, b *Board) (int, int, error) {
+	cp.nodesVisited = 0
+	if b.IsOver() {
+		return 0, 0, errors.New("there is no available position")
+	}
+
+	switch cp.difficulty {
+	case Easy:
+		return randomMove(b, cp.rng())
+	case Medium:
+		if cp.rng().Float64() >= mediumOptimalRate {
+			return randomMove(b, cp.rng())
+		}
+	}
+
+	if cp.temperature > 0 {
+		ranked, err := cp.rankMoves(ctx, b)
+		if len(ranked) == 0 {
+			return 0, 0, errors.New("there is no available position")
+		}
+
+		m := sampleMove(ranked, cp.temperature, cp.rng())
+		return m.I, m.J, err
+	}
+
+	if cp.useBook {
+		if pos, ok := openingMove(b, cp.Mark()); ok {
+			return pos.Row, pos.Col, nil
+		}
+	}
+
+	move, nodes, err := cp.searchParallel(ctx, b, cp.maxDepth)
+	cp.nodesVisited = nodes
+	return move.i, move.j, err
+}
+
+// MoveStats describes the search for a move.
+type MoveStats struct {
+	// Duration is the time spent thinking about the move.
+	Duration time.Duration
+	// NodesVisited is the number of positions searched, it is zero if the move was picked without
+	// a search, e.g. at random or from the opening book.
+	NodesVisited int
+}
+
+// GetMoveWithStats returns next move like GetMove, along with how long it took and how many
+// positions were searched.
+func (cp *ComputerPlayer) GetMoveWithStats(b *Board) (i, j int, stats MoveStats, err error) {
+	start := time.Now()
+	i, j, err = cp.GetMove(b)
+	stats = MoveStats{Duration: time.Since(start), NodesVisited: cp.nodesVisited}
+	return i, j, stats, err
+}
+
+// GetMoveTimed returns next move found within the time budget. It searches with an increasing
+// depth limit and keeps the move of the deepest search that completed. If not even a search of one
+// move completed, it still returns a legal move. Every depth gets the node budget of SetMaxNodes,
+// and it returns ErrSearchTruncated if a search ran out of nodes before the time was up. It returns
+// an error if the game is already over.
+func (cp *ComputerPlayer) GetMoveTimed(b *Board, budget time.Duration) (int, int, error) {
+	if b.IsOver() {
+		return 0, 0, errors.New("there is no available position")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+
+	limit := b.EmptyCount()
+	if cp.maxDepth > 0 && cp.maxDepth < limit {
+		limit = cp.maxDepth
+	}
+
+	cp.nodesVisited = 0
+	if cp.useBook {
+		if pos, ok := openingMove(b, cp.Mark()); ok {
+			return pos.Row, pos.Col, nil
+		}
+	}
+
+	var best move
+	var truncated error
+	for depth := 1; depth <= limit; depth++ {
+		m, nodes, err := cp.searchParallel(ctx, b, depth)
+		cp.nodesVisited += nodes
+		if err != nil {
+			if depth == 1 {
+				best = m
+			}
+			if errors.Is(err, ErrSearchTruncated) {
+				truncated = err
+			}
+			break
+		}
+
+		best = m
+	}
+
+	return best.i, best.j, truncated
+}
+
+// Difficulty is a getter for player's difficulty.
+func (cp *ComputerPlayer) Difficulty() Difficulty {
+	return cp.difficulty
+}
+
+// SetMaxDepth limits the number of moves the player looks ahead. Positions beyond the limit are
+// scored by a heuristic evaluation instead of being searched. Zero means no limit.
+func (cp *ComputerPlayer) SetMaxDepth(n int) {
+	cp.maxDepth = n
+}
+
+// SetMaxNodes limits the number of positions the player searches for a move, which bounds the
+// time a move takes on large boards. Once the limit is reached, the player stops searching and
+// returns the best move found so far along with ErrSearchTruncated. Zero means no limit. Like the
+// other settings of the player, the limit is set with a method rather than an exported MaxNodes
+// field.
+func (cp *ComputerPlayer) SetMaxNodes(n int) {
+	cp.maxNodes = n
+}
+
+// SetTemperature makes the player err now and then, more likely on moves that score close to the
+// best move. Every move is picked with a probability proportional to exp(score / t), so a higher
+// temperature spreads the probability towards weaker moves. Zero, the default, always picks the
+// best move.
+func (cp *ComputerPlayer) SetTemperature(t float64) {
+	cp.temperature = t
+}
+
+// SetWeights sets the points the player gives for holding center, corner and edge positions when
+// its search is limited by SetMaxDepth and a position has to be evaluated before the game is over.
+// They shape the style of the player in positions where the lines alone do not decide.
+func (cp *ComputerPlayer) SetWeights(w Weights) {
+	cp.weights = w
+}
+
+// SetRandom sets the source of every random choice of the player, including the choice between
+// equally good moves, so that its moves can be reproduced. Nil restores the package-level source.
+func (cp *ComputerPlayer) SetRandom(r *rand.Rand) {
+	cp.random = r
+}
+
+func (cp *ComputerPlayer) rng() *rand.Rand {
+	if cp.random == nil {
+		return defaultRandom
+	}
+
+	return cp.random
 }
 
 // Mark is a getter for player's mark.
@@ -30,63 +297,359 @@ func (cp *ComputerPlayer) Name() string {
 	return cp.name
 }
 
+// maxScore is the score of winning before any move is made. Every move made decreases the score
+// by one so quicker wins are preferred, and losing is scored the other way around so slower losses
+// are preferred. A draw is worth zero. It is 10 on a 3 by 3 board.
+func maxScore(b *Board) int {
+	return b.rows*b.cols + 1
+}
+
 type move struct {
 	value int
 	i     int
 	j     int
 }
 
-func (cp *ComputerPlayer) minimax(b *board, mark string, depth int) move {
-	if b.winner() != "" || b.emptyCount() == 0 {
+// minimax searches the game tree with alpha-beta pruning and returns the value of the position
+// from the perspective of the computer player. Alpha is the best value the computer player is
+// assured of and beta is the best value its opponents are assured of. If the context of the search
+// is cancelled, it returns the error of the context along with the best move found so far. With
+// more than two players, every mark other than the mark of the computer player is treated as a
+// minimizing opponent.
+func (cp *ComputerPlayer) minimax(s *search, b *Board, mark string, depth, alpha,
+	beta int) (move, error) {
+	color := cp.color(mark)
+	if color > 0 {
+		return cp.negamax(s, b, mark, depth, alpha, beta, color)
+	}
+
+	m, err := cp.negamax(s, b, mark, depth, -beta, -alpha, color)
+	m.value = -m.value
+	return m, err
+}
+
+// color is 1 for the mark of the computer player and -1 for the marks of its opponents.
+func (cp *ComputerPlayer) color(mark string) int {
+	if mark == cp.Mark() {
+		return 1
+	}
+
+	return -1
+}
+
+// negamax is the alpha-beta search written from the perspective of the side to move: color is 1
+// if mark is the mark of the computer player and -1 otherwise, and the value of a position is its
+// value for the computer player multiplied by color. A child on the other side is searched with
+// the window negated and flipped, and its value negated, so that every side maximizes its own
+// value. Once alpha reaches beta, the remaining siblings cannot affect the outcome and they are
+// skipped. If the context of the search is cancelled, it returns the error of the context along
+// with the best move found so far, and ErrSearchTruncated if the node budget runs out.
+func (cp *ComputerPlayer) negamax(s *search, b *Board, mark string, depth, alpha, beta,
+	color int) (move, error) {
+	s.nodesVisited++
+	if s.nodesVisited%cancelCheckInterval == 0 {
+		if err := s.ctx.Err(); err != nil {
+			return move{}, err
+		}
+	}
+
+	if !s.budget.spend() {
+		return move{}, ErrSearchTruncated
+	}
+
+	if b.IsOver() {
 		m := move{}
-		if b.winner() == cp.Mark() {
-			m.value = 10 - depth
-		} else {
-			m.value = depth - 10
+		if b.Winner() == cp.Mark() {
+			m.value = maxScore(b) - depth
+		} else if !b.IsDraw() {
+			m.value = depth - maxScore(b)
 		}
 
-		return m
+		m.value *= color
+		return m, nil
 	}
 
-	moves := []move{}
-	for _, pos := range b.getAvailablePos() {
-		newBoard := b.copy()
-		i, j := pos[0], pos[1]
-		newBoard[i][j] = mark
+	if s.maxDepth > 0 && depth > s.maxDepth {
+		// The heuristic is kept below the score of winning at this depth so that any win or loss
+		// found by the search always outweighs it.
+		limit := maxScore(b) - depth - 1
+		value := cp.evaluate(b, cp.Mark())
+		if value > limit {
+			value = limit
+		}
+		if value < -limit {
+			value = -limit
+		}
 
-		var opponent string
-		if mark == "X" {
-			opponent = "O"
-		} else {
-			opponent = "X"
+		return move{value: color * value}, nil
+	}
+
+	// The same position is always reached at the same depth within a search, so its value can be
+	// reused regardless of the order of moves that led to it. Values are cached from the
+	// perspective of the computer player.
+	var key string
+	if cp.memoize {
+		key = b.Key()
+		if value, ok := s.cache[key][mark]; ok {
+			return move{value: color * value}, nil
 		}
+	}
 
-		m := cp.minimax(newBoard, opponent, depth+1)
+	initAlpha, initBeta := alpha, beta
+
+	opponent := b.Opponent(mark)
+	opponentColor := cp.color(opponent)
+	positions := b.GetAvailablePosOrdered()
+	if depth == 1 {
+		positions = distinctMoves(b, mark, positions)
+	}
+
+	var best move
+	for n, pos := range positions {
+		newBoard := b.pooledCopy()
+		i, j := pos.Row, pos.Col
+		newBoard.PlaceMark(i, j, mark)
+
+		var m move
+		var err error
+		if opponentColor == color {
+			m, err = cp.negamax(s, newBoard, opponent, depth+1, alpha, beta, color)
+		} else {
+			m, err = cp.negamax(s, newBoard, opponent, depth+1, -beta, -alpha, opponentColor)
+			m.value = -m.value
+		}
+		releaseBoard(newBoard)
 		m.i = i
 		m.j = j
+		if err != nil {
+			// The value of a cancelled search is meaningless, but the position is still a legal
+			// move to fall back on.
+			if n == 0 {
+				best = m
+			}
+			return best, err
+		}
+
+		if n == 0 || best.value < m.value {
+			best = m
+		}
+		if alpha < best.value {
+			alpha = best.value
+		}
 
-		moves = append(moves, m)
+		if alpha >= beta {
+			break
+		}
 	}
 
-	// maximize move value
-	if mark == cp.Mark() {
-		max := moves[0]
-		for _, m := range moves {
-			if max.value < m.value {
-				max = m
+	// A value outside of the initial window is only a bound of the true value, so only exact
+	// values are cached.
+	if cp.memoize && initAlpha < best.value && best.value < initBeta {
+		if s.cache[key] == nil {
+			s.cache[key] = make(map[string]int)
+		}
+		s.cache[key][mark] = color * best.value
+	}
+
+	return best, nil
+}
+
+// rootResult is the value of a candidate first move evaluated by a worker.
+type rootResult struct {
+	index int
+	value int
+	nodes int
+	err   error
+}
+
+// searchParallel evaluates every candidate first move in parallel and picks the best one. Every
+// candidate is searched with a full window so that its value is exact. Moves are ordered by:
+//
+//  1. Value. A win scores higher the fewer moves it takes and a loss scores higher the more moves
+//     it takes, see maxScore, so a quicker win is preferred to a slower one and a slower loss to a
+//     quicker one.
+//  2. Distance from the center of the board, closer first.
+//  3. Random choice, using the source of randomness of the player.
+//
+// It also returns the number of positions visited. The game on b must not be over.
+func (cp *ComputerPlayer) searchParallel(ctx context.Context, b *Board, maxDepth int) (move, int,
+	error) {
+	available := b.GetAvailablePosOrdered()
+	positions := distinctMoves(b, cp.Mark(), available)
+	values, completed, nodes, err := cp.scoreMoves(ctx, b, positions, maxDepth)
+
+	// If no candidate completed before the search was cancelled, the first one is still a legal
+	// move to fall back on.
+	best := 0
+	for k := range positions {
+		if completed[k] && (!completed[best] || values[best] < values[k]) {
+			best = k
+		}
+	}
+
+	if !completed[best] {
+		return move{i: positions[best].Row, j: positions[best].Col}, nodes + 1, err
+	}
+
+	// Every position that is symmetric to a best candidate is equally good. The most central of
+	// them are kept, and one of those is picked at random.
+	bestForms := make(map[string]bool)
+	for k := range positions {
+		if completed[k] && values[k] == values[best] {
+			bestForms[childForm(b, positions[k], cp.Mark())] = true
+		}
+	}
+
+	ties := []Position{}
+	for _, pos := range available {
+		if !bestForms[childForm(b, pos, cp.Mark())] {
+			continue
+		}
+
+		// The available positions are ordered by their distance from the center.
+		if len(ties) > 0 && b.centerDistance(pos) > b.centerDistance(ties[0]) {
+			break
+		}
+		ties = append(ties, pos)
+	}
+
+	pos := ties[cp.rng().Intn(len(ties))]
+	return move{value: values[best], i: pos.Row, j: pos.Col}, nodes + 1, err
+}
+
+// scoreMoves searches the positions as first moves in a pool of goroutines, one per CPU, each with
+// a full window so that its value is exact. It returns the value of every position, whether the
+// search of the position completed before ctx was cancelled, and the number of positions visited.
+func (cp *ComputerPlayer) scoreMoves(ctx context.Context, b *Board, positions []Position,
+	maxDepth int) ([]int, []bool, int, error) {
+	jobs := make(chan int)
+	results := make(chan rootResult, len(positions))
+	budget := newNodeBudget(cp.maxNodes)
+
+	workers := runtime.NumCPU()
+	if workers > len(positions) {
+		workers = len(positions)
+	}
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for k := range jobs {
+				newBoard := b.pooledCopy()
+				newBoard.PlaceMark(positions[k].Row, positions[k].Col, cp.Mark())
+
+				s := newSearch(ctx, maxDepth)
+				s.budget = budget
+				m, err := cp.minimax(s, newBoard, b.Opponent(cp.Mark()), 2, math.MinInt32,
+					math.MaxInt32)
+				releaseBoard(newBoard)
+				results <- rootResult{index: k, value: m.value, nodes: s.nodesVisited, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for k := range positions {
+			jobs <- k
+		}
+		close(jobs)
+	}()
+
+	completed := make([]bool, len(positions))
+	values := make([]int, len(positions))
+	nodes := 0
+	var err error
+	for range positions {
+		r := <-results
+		nodes += r.nodes
+		if r.err != nil {
+			err = r.err
+			continue
+		}
+
+		completed[r.index] = true
+		values[r.index] = r.value
+	}
+
+	return values, completed, nodes, err
+}
+
+// distinctMoves removes the positions that lead to a board symmetric to the board of an earlier
+// position, since symmetric boards have the same value.
+func distinctMoves(b *Board, mark string, positions []Position) []Position {
+	seen := make(map[string]bool)
+	distinct := []Position{}
+	for _, pos := range positions {
+		form := childForm(b, pos, mark)
+		if seen[form] {
+			continue
+		}
+
+		seen[form] = true
+		distinct = append(distinct, pos)
+	}
+
+	return distinct
+}
+
+// evaluate scores a position that is not over from the perspective of mark. Every line that can
+// still be completed by mark alone adds a point, and every line that can still be completed by an
+// opponent alone takes a point away. Every position held by mark adds its weight, and every
+// position held by an opponent takes its weight away. Blocked positions are held by no one.
+func (cp *ComputerPlayer) evaluate(b *Board, mark string) int {
+	score := 0
+	for _, line := range b.lines() {
+		owner, contested := empty, false
+		for _, pos := range line {
+			cell := b.grid[pos[0]][pos[1]]
+			if cell == empty {
+				continue
+			}
+
+			if owner == empty {
+				owner = cell
+			} else if owner != cell {
+				contested = true
+				break
 			}
 		}
 
-		return max
+		if contested || owner == empty {
+			continue
+		}
+
+		if owner == mark {
+			score++
+		} else {
+			score--
+		}
 	}
 
-	// minimize move value
-	min := moves[0]
-	for _, m := range moves {
-		if min.value > m.value {
-			min = m
+	for i := range b.grid {
+		for j, cell := range b.grid[i] {
+			switch cell {
+			case empty, blocked:
+			case mark:
+				score += cp.weight(b, i, j)
+			default:
+				score -= cp.weight(b, i, j)
+			}
 		}
 	}
 
-	return min
+	return score
+}
+
+// weight returns the weight of position (i, j) depending on whether it is a center, a corner or an
+// edge position.
+func (cp *ComputerPlayer) weight(b *Board, i, j int) int {
+	lastRow, lastCol := b.rows-1, b.cols-1
+	switch {
+	case (i == 0 || i == lastRow) && (j == 0 || j == lastCol):
+		return cp.weights.Corner
+	case b.centerDistance(Position{Row: i, Col: j}) ==
+		b.centerDistance(Position{Row: lastRow / 2, Col: lastCol / 2}):
+		return cp.weights.Center
+	default:
+		return cp.weights.Edge
+	}
 }
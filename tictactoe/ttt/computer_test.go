@@ -0,0 +1,916 @@
+package ttt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// fullMinimax is the unpruned minimax search, kept as a reference for the pruned implementation.
+func fullMinimax(cp *ComputerPlayer, b *Board, mark string, depth int) move {
+	if b.IsOver() {
+		m := move{}
+		if b.Winner() == cp.Mark() {
+			m.value = maxScore(b) - depth
+		} else if !b.IsDraw() {
+			m.value = depth - maxScore(b)
+		}
+
+		return m
+	}
+
+	opponent := b.Opponent(mark)
+
+	moves := []move{}
+	for _, pos := range b.GetAvailablePosOrdered() {
+		newBoard := b.Copy()
+		i, j := pos.Row, pos.Col
+		newBoard.PlaceMark(i, j, mark)
+
+		m := fullMinimax(cp, newBoard, opponent, depth+1)
+		m.i = i
+		m.j = j
+
+		moves = append(moves, m)
+	}
+
+	best := moves[0]
+	for _, m := range moves {
+		if mark == cp.Mark() && best.value < m.value {
+			best = m
+		}
+		if mark != cp.Mark() && best.value > m.value {
+			best = m
+		}
+	}
+
+	return best
+}
+
+// referenceMinimax is the alpha-beta search with separate branches for the maximizing and the
+// minimizing player, kept as a reference for the negamax implementation.
+func referenceMinimax(cp *ComputerPlayer, s *search, b *Board, mark string, depth, alpha,
+	beta int) (move, error) {
+	s.nodesVisited++
+	if s.nodesVisited%cancelCheckInterval == 0 {
+		if err := s.ctx.Err(); err != nil {
+			return move{}, err
+		}
+	}
+
+	if b.IsOver() {
+		m := move{}
+		if b.Winner() == cp.Mark() {
+			m.value = maxScore(b) - depth
+		} else if !b.IsDraw() {
+			m.value = depth - maxScore(b)
+		}
+
+		return m, nil
+	}
+
+	if s.maxDepth > 0 && depth > s.maxDepth {
+		// The heuristic is kept below the score of winning at this depth so that any win or loss
+		// found by the search always outweighs it.
+		limit := maxScore(b) - depth - 1
+		value := cp.evaluate(b, cp.Mark())
+		if value > limit {
+			value = limit
+		}
+		if value < -limit {
+			value = -limit
+		}
+
+		return move{value: value}, nil
+	}
+
+	// The same position is always reached at the same depth within a search, so its value can be
+	// reused regardless of the order of moves that led to it.
+	var key string
+	if cp.memoize {
+		key = b.Key()
+		if value, ok := s.cache[key][mark]; ok {
+			return move{value: value}, nil
+		}
+	}
+
+	initAlpha, initBeta := alpha, beta
+
+	opponent := b.Opponent(mark)
+	positions := b.GetAvailablePosOrdered()
+	if depth == 1 {
+		positions = distinctMoves(b, mark, positions)
+	}
+
+	var best move
+	for n, pos := range positions {
+		newBoard := b.Copy()
+		i, j := pos.Row, pos.Col
+		newBoard.PlaceMark(i, j, mark)
+
+		m, err := referenceMinimax(cp, s, newBoard, opponent, depth+1, alpha, beta)
+		m.i = i
+		m.j = j
+		if err != nil {
+			// The value of a cancelled search is meaningless, but the position is still a legal
+			// move to fall back on.
+			if n == 0 {
+				best = m
+			}
+			return best, err
+		}
+
+		if mark == cp.Mark() {
+			// maximize move value
+			if n == 0 || best.value < m.value {
+				best = m
+			}
+			if alpha < best.value {
+				alpha = best.value
+			}
+		} else {
+			// minimize move value
+			if n == 0 || best.value > m.value {
+				best = m
+			}
+			if beta > best.value {
+				beta = best.value
+			}
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	// A value outside of the initial window is only a bound of the true value, so only exact
+	// values are cached.
+	if cp.memoize && initAlpha < best.value && best.value < initBeta {
+		if s.cache[key] == nil {
+			s.cache[key] = make(map[string]int)
+		}
+		s.cache[key][mark] = best.value
+	}
+
+	return best, nil
+}
+
+// optimalMoves returns every position where placing the mark of cp scores as well as the best move
+// according to fullMinimax.
+func optimalMoves(cp *ComputerPlayer, b *Board) map[Position]bool {
+	best := fullMinimax(cp, b, cp.Mark(), 1)
+	optimal := make(map[Position]bool)
+	for _, pos := range b.GetAvailablePos() {
+		newBoard := b.Copy()
+		newBoard.PlaceMark(pos.Row, pos.Col, cp.Mark())
+		if fullMinimax(cp, newBoard, b.Opponent(cp.Mark()), 2).value == best.value {
+			optimal[pos] = true
+		}
+	}
+
+	return optimal
+}
+
+// fixtureBoards returns a set of fixed positions where it is O's turn.
+func fixtureBoards() []*Board {
+	return []*Board{
+		boardOf(
+			[]string{"X", "_", "_"},
+			[]string{"_", "_", "_"},
+			[]string{"_", "_", "_"},
+		),
+		boardOf(
+			[]string{"_", "_", "_"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "_"},
+		),
+		boardOf(
+			[]string{"X", "O", "_"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "_"},
+		),
+		boardOf(
+			[]string{"X", "_", "_"},
+			[]string{"_", "O", "_"},
+			[]string{"_", "_", "X"},
+		),
+		boardOf(
+			[]string{"O", "X", "X"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "_", "_"},
+		),
+		boardOf(
+			[]string{"X", "O", "X"},
+			[]string{"_", "O", "_"},
+			[]string{"_", "X", "_"},
+		),
+	}
+}
+
+func TestMinimaxPruningAgreesWithFullSearch(t *testing.T) {
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	for _, b := range fixtureBoards() {
+		optimal := optimalMoves(cp, b)
+		i, j, err := cp.GetMove(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !optimal[Position{i, j}] {
+			t.Errorf("pruned move (%d, %d) is not one of the full search moves %v on board\n%s", i, j,
+				optimal, b)
+		}
+	}
+}
+
+func TestComputerPlayerGameOver(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "X", "X"},
+		[]string{"O", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	for _, d := range []Difficulty{Easy, Hard} {
+		cp := NewComputerPlayer("HAL9000", "O", d)
+		if _, _, err := cp.GetMove(b); err == nil {
+			t.Errorf("difficulty %d should return an error on a board that is won", d)
+		}
+
+		if _, _, err := cp.GetMoveTimed(b, time.Second); err == nil {
+			t.Errorf("timed move of difficulty %d should return an error on a board that is won", d)
+		}
+	}
+}
+
+func TestEasyComputerPlayer(t *testing.T) {
+	b := boardOf(
+		[]string{"O", "O", "_"},
+		[]string{"X", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	hard := NewComputerPlayer("HAL9000", "O", Hard)
+	if i, j, _ := hard.GetMove(b); i != 0 || j != 2 {
+		t.Fatalf("hard move (%d, %d) != winning move (0, 2)", i, j)
+	}
+
+	easy := NewComputerPlayer("HAL9000", "O", Easy)
+	easy.random = rand.New(rand.NewSource(1))
+	i, j, err := easy.GetMove(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !b.IsLegalMove(i, j) {
+		t.Errorf("easy move (%d, %d) is not legal", i, j)
+	}
+
+	if i == 0 && j == 2 {
+		t.Errorf("easy move (%d, %d) should not be the optimal move with this seed", i, j)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	b := boardOf(
+		[]string{"O", "_", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	// O owns the top row and the left column, X owns the middle row, the middle column and the
+	// anti-diagonal, and the diagonal is contested.
+	if score := cp.evaluate(b, "O"); score != -1 {
+		t.Errorf("score %d != -1", score)
+	}
+
+	if score := cp.evaluate(b, "X"); score != 1 {
+		t.Errorf("score %d != 1", score)
+	}
+}
+
+func TestEvaluateBlocks(t *testing.T) {
+	b, _ := NewBoardWithBlocks(3, []Position{{Row: 1, Col: 1}})
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	for _, mark := range []string{"X", "O"} {
+		if score := cp.evaluate(b, mark); score != 0 {
+			t.Errorf("score %d != 0 for %s on an empty board with a block", score, mark)
+		}
+	}
+}
+
+func TestComputerPlayerWeights(t *testing.T) {
+	// Corners and central positions of a 4 by 4 board lie on three lines each, so the lines alone
+	// leave the first move to the weights.
+	centers := [][2]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}}
+	corners := [][2]int{{0, 0}, {0, 3}, {3, 0}, {3, 3}}
+
+	testCases := []struct {
+		name     string
+		weights  Weights
+		expected [][2]int
+	}{
+		{name: "Center", weights: Weights{Center: 5}, expected: centers},
+		{name: "Corner", weights: Weights{Corner: 5}, expected: corners},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cp := NewComputerPlayer("HAL9000", "X", Hard)
+			cp.SetMaxDepth(1)
+			cp.SetWeights(tc.weights)
+			cp.SetRandom(rand.New(rand.NewSource(42)))
+
+			i, j, err := cp.GetMove(NewBoard(4))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, pos := range tc.expected {
+				if pos == [2]int{i, j} {
+					return
+				}
+			}
+
+			t.Errorf("move (%d, %d) is not one of %v", i, j, tc.expected)
+		})
+	}
+}
+
+func TestDepthLimitedMinimax(t *testing.T) {
+	testCases := []struct {
+		board    *Board
+		expected [2]int
+	}{
+		{
+			board: boardOf(
+				[]string{"X", "X", "X", "_"},
+				[]string{"O", "O", "_", "_"},
+				[]string{"_", "_", "_", "_"},
+				[]string{"_", "_", "_", "_"},
+			),
+			expected: [2]int{0, 3},
+		},
+		{
+			board: boardOf(
+				[]string{"X", "X", "_", "X"},
+				[]string{"O", "O", "O", "_"},
+				[]string{"X", "_", "_", "_"},
+				[]string{"_", "_", "_", "_"},
+			),
+			expected: [2]int{1, 3},
+		},
+	}
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	cp.SetMaxDepth(3)
+	for _, tc := range testCases {
+		i, j, err := cp.GetMove(tc.board)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if i != tc.expected[0] || j != tc.expected[1] {
+			t.Errorf("move (%d, %d) != expected move %v on board\n%s", i, j, tc.expected, tc.board)
+		}
+	}
+}
+
+func TestMinimaxMemoizationAgreesWithoutCache(t *testing.T) {
+	memoized := NewComputerPlayer("HAL9000", "O", Hard)
+	plain := NewComputerPlayer("HAL9000", "O", Hard)
+	plain.memoize = false
+
+	for _, b := range fixtureBoards() {
+		optimal := optimalMoves(plain, b)
+		i, j, _ := memoized.GetMove(b)
+		expectedI, expectedJ, _ := plain.GetMove(b)
+		if !optimal[Position{i, j}] || !optimal[Position{expectedI, expectedJ}] {
+			t.Errorf("memoized move (%d, %d) and move (%d, %d) without cache should be one of %v on "+
+				"board\n%s", i, j, expectedI, expectedJ, optimal, b)
+		}
+
+		if memoized.nodesVisited > plain.nodesVisited {
+			t.Errorf("memoized search visited %d nodes > %d nodes without cache",
+				memoized.nodesVisited, plain.nodesVisited)
+		}
+	}
+}
+
+func TestDistinctMoves(t *testing.T) {
+	b := NewBoard(3)
+	moves := distinctMoves(b, "X", b.GetAvailablePos())
+
+	// A corner, an edge and the center.
+	expected := []Position{{0, 0}, {0, 1}, {1, 1}}
+	if len(moves) != len(expected) {
+		t.Fatalf("distinct moves %v != %v", moves, expected)
+	}
+
+	for k := range expected {
+		if moves[k] != expected[k] {
+			t.Errorf("distinct moves %v != %v", moves, expected)
+		}
+	}
+}
+
+func TestGetMoveContextCancellation(t *testing.T) {
+	b := NewBoard(4)
+	b.PlaceMark(0, 0, "X")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	start := time.Now()
+	i, j, err := cp.GetMoveContext(ctx, b)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("search took %v after cancellation", elapsed)
+	}
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("error %v != %v", err, context.DeadlineExceeded)
+	}
+
+	if !b.IsLegalMove(i, j) {
+		t.Errorf("move (%d, %d) is not legal", i, j)
+	}
+}
+
+func TestMaxNodes(t *testing.T) {
+	b := NewBoard(7)
+	b.PlaceMark(3, 3, "X")
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	cp.SetMaxNodes(100)
+	i, j, stats, err := cp.GetMoveWithStats(b)
+	if !errors.Is(err, ErrSearchTruncated) {
+		t.Errorf("error %v != %v", err, ErrSearchTruncated)
+	}
+
+	if !b.IsLegalMove(i, j) {
+		t.Errorf("move (%d, %d) is not legal", i, j)
+	}
+
+	// The search of every candidate move counts the position it stops at on top of the budget.
+	if limit := 100 + len(b.GetAvailablePos()) + 1; stats.NodesVisited > limit {
+		t.Errorf("actual %d nodes visited with a budget of 100", stats.NodesVisited)
+	}
+
+	cp = NewComputerPlayer("HAL9000", "O", Hard)
+	cp.SetMaxNodes(1000000)
+	if _, _, err := cp.GetMove(midGameBoard()); err != nil {
+		t.Errorf("unexpected error with a budget that is large enough: %v", err)
+	}
+}
+
+func TestGetMoveTimed(t *testing.T) {
+	t.Run("SmallBudget", func(t *testing.T) {
+		b := NewBoard(4)
+		b.PlaceMark(1, 1, "X")
+
+		cp := NewComputerPlayer("HAL9000", "O", Hard)
+		budget := 50 * time.Millisecond
+		start := time.Now()
+		i, j, err := cp.GetMoveTimed(b, budget)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > budget+200*time.Millisecond {
+			t.Errorf("search took %v with a budget of %v", elapsed, budget)
+		}
+
+		if !b.IsLegalMove(i, j) {
+			t.Errorf("move (%d, %d) is not legal", i, j)
+		}
+	})
+
+	t.Run("LargeBudget", func(t *testing.T) {
+		cp := NewComputerPlayer("HAL9000", "O", Hard)
+		for _, b := range fixtureBoards() {
+			optimal := optimalMoves(cp, b)
+			if i, j, _ := cp.GetMoveTimed(b, time.Minute); !optimal[Position{i, j}] {
+				t.Errorf("timed move (%d, %d) is not one of the optimal moves %v on board\n%s", i, j,
+					optimal, b)
+			}
+		}
+	})
+}
+
+func TestParallelSearchAgreesWithSequential(t *testing.T) {
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	boards := append(fixtureBoards(), NewBoard(3))
+	for _, b := range boards {
+		expected, _ := cp.minimax(newSearch(context.Background(), 0), b, cp.Mark(), 1, math.MinInt32,
+			math.MaxInt32)
+
+		// The full search of the empty board is too slow, so the values of the moves are taken
+		// from the sequential search.
+		optimal := make(map[Position]bool)
+		for _, pos := range b.GetAvailablePos() {
+			newBoard := b.Copy()
+			newBoard.PlaceMark(pos.Row, pos.Col, cp.Mark())
+			m, _ := cp.minimax(newSearch(context.Background(), 0), newBoard, b.Opponent(cp.Mark()), 2,
+				math.MinInt32, math.MaxInt32)
+			if m.value == expected.value {
+				optimal[pos] = true
+			}
+		}
+
+		i, j, err := cp.GetMove(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !optimal[Position{i, j}] || !optimal[Position{expected.i, expected.j}] {
+			t.Errorf("parallel move (%d, %d) and sequential move (%d, %d) should be one of %v on "+
+				"board\n%s", i, j, expected.i, expected.j, optimal, b)
+		}
+	}
+}
+
+func TestMinimaxWithCustomMarks(t *testing.T) {
+	b := NewBoard(3)
+	if err := b.SetMarks("A", "B"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.PlaceMark(0, 0, "A")
+	b.PlaceMark(1, 1, "B")
+	b.PlaceMark(2, 2, "A")
+	b.PlaceMark(0, 2, "B")
+	b.PlaceMark(2, 0, "A")
+	b.PlaceMark(1, 0, "B")
+
+	// A wins on the bottom row before B completes the middle row.
+	cp := NewComputerPlayer("HAL9000", "A", Hard)
+	i, j, err := cp.GetMove(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 2 || j != 1 {
+		t.Errorf("move (%d, %d) != winning move (2, 1) on board\n%s", i, j, b)
+	}
+}
+
+func TestMinimaxWithThreePlayers(t *testing.T) {
+	b, _ := NewBoardWithWin(4, 3)
+	b.SetMarks("X", "O", "△")
+	b.PlaceMark(0, 0, "X")
+	b.PlaceMark(3, 3, "O")
+	b.PlaceMark(1, 0, "△")
+	b.PlaceMark(0, 1, "X")
+	b.PlaceMark(2, 3, "O")
+	b.PlaceMark(1, 1, "△")
+
+	// X wins right away rather than blocking either O or △.
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	cp.SetMaxDepth(3)
+	i, j, err := cp.GetMove(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 0 || j != 2 {
+		t.Errorf("move (%d, %d) != winning move (0, 2) on board\n%s", i, j, b)
+	}
+}
+
+func TestOpeningBook(t *testing.T) {
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	i, j, err := cp.GetMove(NewBoard(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if (i+j)%2 == 1 {
+		t.Errorf("opening move (%d, %d) is neither a corner nor the center", i, j)
+	}
+
+	if cp.nodesVisited != 0 {
+		t.Errorf("nodes visited %d != 0", cp.nodesVisited)
+	}
+
+	// A reply to a corner is looked up through the symmetry of the board.
+	b := NewBoard(3)
+	b.PlaceMark(2, 0, "X")
+	cp = NewComputerPlayer("HAL9000", "O", Hard)
+	if i, j, _ := cp.GetMove(b); i != 1 || j != 1 {
+		t.Errorf("reply (%d, %d) != center (1, 1) on board\n%s", i, j, b)
+	}
+
+	if cp.nodesVisited != 0 {
+		t.Errorf("nodes visited %d != 0", cp.nodesVisited)
+	}
+}
+
+func TestComputerPlayerSeed(t *testing.T) {
+	play := func() []Move {
+		p1 := NewComputerPlayer("HAL9000", "X", Medium)
+		p1.SetRandom(rand.New(rand.NewSource(1)))
+		p2 := NewComputerPlayer("Deep Thought", "O", Hard)
+		p2.SetRandom(rand.New(rand.NewSource(2)))
+
+		b := NewBoard(3)
+		g := mustNewGame(t, p1, p2, WithBoard(b), WithOutput(&bytes.Buffer{}))
+		if _, err := g.Play(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return b.History()
+	}
+
+	first, second := play(), play()
+	if len(first) != len(second) {
+		t.Fatalf("moves %v != moves %v with the same seeds", first, second)
+	}
+
+	for k := range first {
+		if first[k] != second[k] {
+			t.Errorf("moves %v != moves %v with the same seeds", first, second)
+			break
+		}
+	}
+}
+
+func TestMinimaxPrefersQuickerWin(t *testing.T) {
+	// Both the top right corner and the center win, but the corner wins right away.
+	b := boardOf(
+		[]string{"X", "X", "_"},
+		[]string{"O", "_", "_"},
+		[]string{"O", "_", "_"},
+	)
+
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	if i, j, _ := cp.GetMove(b); i != 0 || j != 2 {
+		t.Errorf("move (%d, %d) != quicker win (0, 2) on board\n%s", i, j, b)
+	}
+
+	ranked := cp.RankMoves(b)
+	center := 0
+	for _, m := range ranked {
+		if m.I == 1 && m.J == 1 {
+			center = m.Score
+		}
+	}
+
+	if center <= 0 || center >= ranked[0].Score {
+		t.Errorf("center score %d should be a win scoring lower than %+v", center, ranked[0])
+	}
+}
+
+func TestMinimaxDelaysLoss(t *testing.T) {
+	// X wins whatever O does, but blocking the left column at (2, 0) makes X need a fork.
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"X", "_", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	// plies counts the moves left in the game after O plays on pos and both sides play on
+	// perfectly.
+	plies := func(pos Position) int {
+		c := b.Copy()
+		c.PlaceMark(pos.Row, pos.Col, "O")
+		n := 1
+		for ; !c.IsOver(); n++ {
+			i, j, _ := NewComputerPlayer("HAL9000", c.CurrentMark(), Hard).GetMove(c)
+			c.PlaceMark(i, j, c.CurrentMark())
+		}
+
+		if c.Winner() != "X" {
+			t.Fatalf("X should win after O plays %v\n%s", pos, c)
+		}
+		return n
+	}
+
+	longest := 0
+	for _, pos := range b.GetAvailablePos() {
+		longest = max(longest, plies(pos))
+	}
+
+	i, j, _ := NewComputerPlayer("HAL9000", "O", Hard).GetMove(b)
+	if i != 2 || j != 0 {
+		t.Errorf("move (%d, %d) != slowest loss (2, 0) on board\n%s", i, j, b)
+	}
+
+	if n := plies(Position{Row: i, Col: j}); n != longest {
+		t.Errorf("plies %d after move (%d, %d) != longest %d", n, i, j, longest)
+	}
+}
+
+func TestMinimaxPrefersCentralTies(t *testing.T) {
+	// Every first move is a draw, the center is the most central of them.
+	for seed := int64(0); seed < 5; seed++ {
+		cp := NewComputerPlayer("HAL9000", "X", Hard)
+		cp.useBook = false
+		cp.SetRandom(rand.New(rand.NewSource(seed)))
+		if i, j, _ := cp.GetMove(NewBoard(3)); i != 1 || j != 1 {
+			t.Errorf("move (%d, %d) != center (1, 1) with seed %d", i, j, seed)
+		}
+	}
+}
+
+func TestGetMoveWithStats(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	i, j, stats, err := cp.GetMoveWithStats(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 2 || j != 2 {
+		t.Errorf("move (%d, %d) != blocking move (2, 2)", i, j)
+	}
+
+	if stats.NodesVisited <= 0 {
+		t.Errorf("nodes visited %d should be positive", stats.NodesVisited)
+	}
+
+	if stats.Duration <= 0 {
+		t.Errorf("duration %v should be positive", stats.Duration)
+	}
+
+	if _, _, stats, _ := cp.GetMoveWithStats(NewBoard(3)); stats.NodesVisited != 0 {
+		t.Errorf("nodes visited %d != 0 for a move from the opening book", stats.NodesVisited)
+	}
+}
+
+func TestNegamaxAgreesWithReference(t *testing.T) {
+	threePlayers, _ := NewBoardWithWin(4, 3)
+	threePlayers.SetMarks("X", "O", "△")
+	threePlayers.PlaceMark(0, 0, "X")
+	threePlayers.PlaceMark(3, 3, "O")
+	threePlayers.PlaceMark(1, 0, "△")
+
+	testCases := []struct {
+		board    *Board
+		mark     string
+		maxDepth int
+	}{
+		{board: NewBoard(3), mark: "X"},
+		{board: midGameBoard(), mark: "X"},
+		{board: NewBoard(4), mark: "X", maxDepth: 3},
+		{board: threePlayers, mark: "X", maxDepth: 3},
+	}
+	for _, b := range fixtureBoards() {
+		testCases = append(testCases, struct {
+			board    *Board
+			mark     string
+			maxDepth int
+		}{board: b, mark: "O"})
+	}
+
+	for _, tc := range testCases {
+		for _, memoize := range []bool{true, false} {
+			cp := NewComputerPlayer("HAL9000", tc.mark, Hard)
+			cp.memoize = memoize
+
+			expected, _ := referenceMinimax(cp, newSearch(context.Background(), tc.maxDepth), tc.board,
+				tc.mark, 1, math.MinInt32, math.MaxInt32)
+			m, _ := cp.minimax(newSearch(context.Background(), tc.maxDepth), tc.board, tc.mark, 1,
+				math.MinInt32, math.MaxInt32)
+			if m != expected {
+				t.Errorf("negamax move %+v != reference move %+v on board\n%s", m, expected, tc.board)
+			}
+
+			// The opponent to move is searched from the other side.
+			opponent := tc.board.Opponent(tc.mark)
+			expected, _ = referenceMinimax(cp, newSearch(context.Background(), tc.maxDepth),
+				tc.board, opponent, 1, math.MinInt32, math.MaxInt32)
+			m, _ = cp.minimax(newSearch(context.Background(), tc.maxDepth), tc.board, opponent, 1,
+				math.MinInt32, math.MaxInt32)
+			if m != expected {
+				t.Errorf("negamax move %+v != reference move %+v for %s on board\n%s", m, expected,
+					opponent, tc.board)
+			}
+		}
+	}
+}
+
+// win, loss and draw are game-theoretic values for the mark to move: a win or a loss after n more
+// moves are played, or a draw.
+func win(n int) int  { return 100 - n }
+func loss(n int) int { return n - 100 }
+
+const draw = 0
+
+// solve returns the game-theoretic value of a two player 3 by 3 board for the mark to move, with
+// a plain search of the whole game tree that shares no code with the search of ComputerPlayer.
+func solve(b *Board, cache map[string]int) int {
+	key := b.Key() + b.CurrentMark()
+	if value, ok := cache[key]; ok {
+		return value
+	}
+
+	value := loss(0)
+	if b.Winner() == "" {
+		if b.Full() {
+			value = draw
+		} else {
+			for _, pos := range b.GetAvailablePos() {
+				value = max(value, moveValue(b, pos, cache))
+			}
+		}
+	}
+
+	cache[key] = value
+	return value
+}
+
+// moveValue returns the game-theoretic value of playing on pos for the mark to move.
+func moveValue(b *Board, pos Position, cache map[string]int) int {
+	child := b.Copy()
+	child.PlaceMark(pos.Row, pos.Col, b.CurrentMark())
+
+	// The value for the opponent is negated and the move played is one more move to the end.
+	value := -solve(child, cache)
+	switch {
+	case value > 0:
+		value--
+	case value < 0:
+		value++
+	}
+
+	return value
+}
+
+func TestComputerPlayerOracle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    *Board
+		expected int
+	}{
+		{name: "empty board", board: NewBoard(3), expected: draw},
+		{name: "center taken", board: fixtureBoards()[1], expected: draw},
+		{name: "opposite corners", board: midGameBoard(), expected: draw},
+		{
+			name: "win right away",
+			board: boardOf(
+				[]string{"X", "X", "_"},
+				[]string{"O", "_", "_"},
+				[]string{"O", "_", "_"},
+			),
+			expected: win(1),
+		},
+		{
+			name: "win with a fork",
+			board: boardOf(
+				[]string{"X", "O", "_"},
+				[]string{"_", "X", "_"},
+				[]string{"_", "_", "O"},
+			),
+			expected: win(3),
+		},
+		{
+			name: "delay the loss",
+			board: boardOf(
+				[]string{"X", "O", "_"},
+				[]string{"X", "_", "_"},
+				[]string{"_", "_", "_"},
+			),
+			expected: loss(4),
+		},
+		{
+			name: "block the win",
+			board: boardOf(
+				[]string{"_", "O", "_"},
+				[]string{"_", "_", "_"},
+				[]string{"X", "X", "_"},
+			),
+			expected: draw,
+		},
+	}
+
+	cache := make(map[string]int)
+	for _, tc := range testCases {
+		if value := solve(tc.board, cache); value != tc.expected {
+			t.Fatalf("%s: oracle value %d != known value %d", tc.name, value, tc.expected)
+		}
+
+		for _, memoize := range []bool{true, false} {
+			cp := NewComputerPlayer("HAL9000", tc.board.CurrentMark(), Hard)
+			cp.memoize = memoize
+			i, j, err := cp.GetMove(tc.board)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if value := moveValue(tc.board, Position{Row: i, Col: j}, cache); value != tc.expected {
+				t.Errorf("%s: move (%d, %d) has value %d != optimal value %d with memoize %v", tc.name,
+					i, j, value, tc.expected, memoize)
+			}
+		}
+	}
+}
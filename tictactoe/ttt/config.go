@@ -0,0 +1,91 @@
+package ttt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+const (
+	// MinGameSize and MaxGameSize bound the size of the board of a game, see GameConfig.
+	MinGameSize = 3
+	MaxGameSize = 5
+
+	// LargeBoardMaxDepth limits the search of a computer player on boards larger than 3 by 3, a
+	// full search of those boards takes too long to play against or to answer a request.
+	LargeBoardMaxDepth = 4
+)
+
+// Opponent is the kind of player who plays O against the human player playing X.
+type Opponent int
+
+const (
+	// HumanOpponent is a second human player who reads moves from the same input.
+	HumanOpponent Opponent = iota
+	// RandomOpponent picks any available position.
+	RandomOpponent
+	// ComputerOpponent searches for a move at the difficulty of the config.
+	ComputerOpponent
+)
+
+// GameConfig describes a game between a human player playing X and an opponent playing O. It is
+// filled in by the menu or by command line flags.
+type GameConfig struct {
+	Size       int
+	Opponent   Opponent
+	Difficulty Difficulty
+
+	// First is the mark of the player who moves first, X if it is empty.
+	First string
+}
+
+// NewGame creates the game described by the config. The human players read their moves from in,
+// and the game is printed to out, in color if out is a terminal. It returns an error if the size
+// is not between 3 and 5, the opponent or the difficulty is unknown, or First is neither X nor O.
+func (c GameConfig) NewGame(in io.Reader, out io.Writer) (*Game, error) {
+	if c.Size < MinGameSize || c.Size > MaxGameSize {
+		return nil, fmt.Errorf("board size %d must be between %d and %d", c.Size, MinGameSize,
+			MaxGameSize)
+	}
+
+	if c.Difficulty < Easy || c.Difficulty > Hard {
+		return nil, fmt.Errorf("unknown difficulty %d", c.Difficulty)
+	}
+
+	// Both human players read from the same buffer so that neither reads ahead the moves of the
+	// other.
+	r, ok := in.(*bufio.Reader)
+	if !ok {
+		r = bufio.NewReader(in)
+	}
+
+	p1 := NewHumanPlayerWithIO("Player 1", "X", r, out)
+
+	var p2 Player
+	switch c.Opponent {
+	case HumanOpponent:
+		p2 = NewHumanPlayerWithIO("Player 2", "O", r, out)
+	case RandomOpponent:
+		p2 = NewRandomPlayer("Random", "O", defaultRandom)
+	case ComputerOpponent:
+		cp := NewComputerPlayer("HAL9000", "O", c.Difficulty)
+		if c.Size > 3 {
+			cp.SetMaxDepth(LargeBoardMaxDepth)
+		}
+		p2 = cp
+	default:
+		return nil, fmt.Errorf("unknown opponent %d", c.Opponent)
+	}
+
+	first := 0
+	switch c.First {
+	case "", p1.Mark():
+	case p2.Mark():
+		first = 1
+	default:
+		return nil, fmt.Errorf("first mark %q must be %s or %s", c.First, p1.Mark(), p2.Mark())
+	}
+
+	return NewGame(p1, p2, WithBoard(NewBoard(c.Size)), WithOutput(out),
+		WithRenderer(NewColorRenderer(out)), WithFirstPlayer(first))
+}
@@ -0,0 +1,40 @@
+package ttt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGameConfig(t *testing.T) {
+	t.Run("HumanOpponentMovesFirst", func(t *testing.T) {
+		cfg := GameConfig{Size: 3, Opponent: HumanOpponent, First: "O"}
+		g, err := cfg.NewGame(strings.NewReader("0 0\n1 0\n0 1\n1 1\n0 2\n"), &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		winner, err := g.Play()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winner == nil || winner.Mark() != "O" {
+			t.Errorf("winner %v != O", winner)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, cfg := range []GameConfig{
+			{Size: 2},
+			{Size: 6},
+			{Size: 3, Opponent: Opponent(3)},
+			{Size: 3, Opponent: ComputerOpponent, Difficulty: Difficulty(5)},
+			{Size: 3, First: "Z"},
+		} {
+			if _, err := cfg.NewGame(strings.NewReader(""), &bytes.Buffer{}); err == nil {
+				t.Errorf("expected error for config %+v", cfg)
+			}
+		}
+	})
+}
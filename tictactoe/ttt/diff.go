@@ -0,0 +1,33 @@
+package ttt
+
+import "fmt"
+
+// CellChange is a position (I, J) whose mark differs between two boards. Old is the mark on the
+// earlier board and New the mark on the later board, an empty string if no mark occupies the
+// position.
+type CellChange struct {
+	I   int
+	J   int
+	Old string
+	New string
+}
+
+// Diff returns the positions whose mark changed since prev, in row-major order, so that a user
+// interface can redraw only those. A blocked position counts as empty, like in MarkAt. It returns
+// an error if the boards are not the same shape.
+func (b *Board) Diff(prev *Board) ([]CellChange, error) {
+	if b.rows != prev.rows || b.cols != prev.cols {
+		return nil, fmt.Errorf("cannot diff a %d by %d board against a %d by %d board", b.rows,
+			b.cols, prev.rows, prev.cols)
+	}
+
+	changes := []CellChange{}
+	b.ForEachCell(func(i, j int, mark string) {
+		old, _ := prev.MarkAt(i, j)
+		if old != mark {
+			changes = append(changes, CellChange{I: i, J: j, Old: old, New: mark})
+		}
+	})
+
+	return changes, nil
+}
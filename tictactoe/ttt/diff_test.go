@@ -0,0 +1,38 @@
+package ttt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	b := midGameBoard()
+	prev := b.Copy()
+	if err := b.PlaceMark(0, 2, b.CurrentMark()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changes, err := b.Diff(prev)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []CellChange{{I: 0, J: 2, Old: "", New: prev.CurrentMark()}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("actual %+v != expected %+v", changes, expected)
+	}
+
+	if changes, _ := b.Diff(b.Copy()); len(changes) != 0 {
+		t.Errorf("a board should not differ from its copy, got %+v", changes)
+	}
+
+	// Undoing the move is the reverse change.
+	if changes, _ := prev.Diff(b); len(changes) != 1 || changes[0].Old != expected[0].New ||
+		changes[0].New != "" {
+		t.Errorf("reverse diff %+v should undo %+v", changes, expected)
+	}
+
+	if _, err := b.Diff(NewBoard(4)); err == nil {
+		t.Error("diffing boards of different sizes should return an error")
+	}
+}
@@ -0,0 +1,48 @@
+package ttt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalMove is the error wrapped by every IllegalMoveError. Callers can check for it with
+// errors.Is to decide whether to ask for another move.
+var ErrIllegalMove = errors.New("illegal move")
+
+// ErrResigned is returned by a player who concedes the game instead of making a move. The game
+// treats it as a loss for that player rather than as a failure.
+var ErrResigned = errors.New("player resigned")
+
+// ErrUndo is returned by a player who takes back the last turn instead of making a move. The game
+// undoes the turn with Game.UndoLastTurn and asks the same player again.
+var ErrUndo = errors.New("player asked to undo")
+
+// ErrInputClosed is returned by a player whose input ends before a move is entered, e.g. when piped
+// input runs out. The game stops instead of asking for another move.
+var ErrInputClosed = errors.New("input closed")
+
+// ErrMoveTimeout is returned by a player who does not make a move in time, see
+// HumanPlayer.SetMoveTimeout. The game treats it as a loss for that player, like ErrResigned.
+var ErrMoveTimeout = errors.New("move timed out")
+
+// ErrSearchTruncated is returned by a computer player whose search visits more positions than its
+// node budget allows, see ComputerPlayer.SetMaxNodes. It comes along with the best legal move found
+// before the search stopped.
+var ErrSearchTruncated = errors.New("search truncated")
+
+// IllegalMoveError is returned when a mark cannot be placed on position (I, J).
+type IllegalMoveError struct {
+	I      int
+	J      int
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *IllegalMoveError) Error() string {
+	return fmt.Sprintf("illegal move (%d, %d): %s", e.I, e.J, e.Reason)
+}
+
+// Unwrap returns ErrIllegalMove.
+func (e *IllegalMoveError) Unwrap() error {
+	return ErrIllegalMove
+}
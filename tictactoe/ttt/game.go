@@ -1,71 +1,412 @@
 package ttt
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
 	"strconv"
 )
 
-// NewGame is a constructor for a game.
-func NewGame(p1 Player, p2 Player) *Game {
-	return &Game{
-		p1:      p1,
-		p2:      p2,
-		current: p1,
-		board:   newBoard(),
+// GameOption configures optional settings of a game.
+type GameOption func(*Game)
+
+// WithOutput sets the writer that game messages are printed to. It defaults to standard output.
+func WithOutput(w io.Writer) GameOption {
+	return func(g *Game) {
+		g.out = w
+	}
+}
+
+// WithBoard sets the board the game is played on. It defaults to an empty 3 by 3 board.
+func WithBoard(b *Board) GameOption {
+	return func(g *Game) {
+		g.board = b
+	}
+}
+
+// WithForcedDrawDetection ends the game as a draw as soon as neither player can force a win. The
+// position is checked after every move with a full search of the game tree, see
+// Board.IsForcedDraw, so forced draw detection is meant for small boards such as 3 by 3.
+func WithForcedDrawDetection() GameOption {
+	return func(g *Game) {
+		g.stopOnForcedDraw = true
+	}
+}
+
+// WithRenderer sets how the board is rendered when it is printed. It defaults to Board.String.
+func WithRenderer(r Renderer) GameOption {
+	return func(g *Game) {
+		g.renderer = r
+	}
+}
+
+// WithScoreboard records the result of the game on s once it is over.
+func WithScoreboard(s *Scoreboard) GameOption {
+	return func(g *Game) {
+		g.scoreboard = s
+	}
+}
+
+// WithFirstPlayer lets the player at index k of the players move first instead of the player
+// whose mark is the mark to move on the board. It cannot be used on a board that already has moves.
+func WithFirstPlayer(k int) GameOption {
+	return func(g *Game) {
+		g.first = &k
+	}
+}
+
+// NewGame is a constructor for a game between two players. It returns an error if the players
+// cannot play each other on the board, see NewMultiplayerGame.
+func NewGame(p1 Player, p2 Player, opts ...GameOption) (*Game, error) {
+	return NewMultiplayerGame([]Player{p1, p2}, opts...)
+}
+
+// NewMultiplayerGame is a constructor for a game where players take turns in the given order. The
+// marks of the board are set to the marks of the players. It returns an error if a player has no
+// mark, two players share a mark, or the board already has moves and a mark of a player is not one
+// of the marks of the board. It also returns an error if the first player is out of range or the
+// board already has moves.
+func NewMultiplayerGame(players []Player, opts ...GameOption) (*Game, error) {
+	g := &Game{
+		players: players,
+		board:   NewBoard(3),
 		round:   1,
+		out:     os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	owners := make(map[string]Player)
+	marks := make([]string, len(players))
+	for k, p := range players {
+		if p.Mark() == "" {
+			return nil, fmt.Errorf("player %s has no mark", p.Name())
+		}
+
+		if owner, ok := owners[p.Mark()]; ok {
+			return nil, fmt.Errorf("players %s and %s share mark %q", owner.Name(), p.Name(),
+				p.Mark())
+		}
+		owners[p.Mark()] = p
+
+		// The marks of a board in play cannot change.
+		if len(g.board.history) > 0 && !g.board.isMark(p.Mark()) {
+			return nil, fmt.Errorf("mark %q of player %s is not one of the marks %v of the board",
+				p.Mark(), p.Name(), g.board.Marks())
+		}
+		marks[k] = p.Mark()
+	}
+
+	if err := g.board.SetMarks(marks...); err != nil {
+		return nil, err
 	}
+
+	if g.first != nil {
+		if *g.first < 0 || *g.first >= len(players) {
+			return nil, fmt.Errorf("first player %d must be between 0 and %d", *g.first,
+				len(players)-1)
+		}
+
+		if len(g.board.history) > 0 {
+			return nil, errors.New("the first player cannot be chosen on a board with moves")
+		}
+
+		if err := g.board.SetStartingMark(players[*g.first].Mark()); err != nil {
+			return nil, err
+		}
+	}
+
+	// A board that is already in play decides who moves next.
+	for k, p := range players {
+		if p.Mark() == g.board.CurrentMark() {
+			g.current = k
+			break
+		}
+	}
+
+	return g, nil
 }
 
 // Game keeps track of the progress of a tic tac toe game.
 type Game struct {
-	p1      Player
-	p2      Player
-	current Player
-	board   *board
+	players []Player
+	current int
+	board   *Board
 	round   int
+	out     io.Writer
+
+	// first is the index of the player who moves first, or nil if the board decides.
+	first *int
+
+	// started is set once the first step is played, and over and winner once the game is over.
+	started bool
+	over    bool
+	winner  Player
+
+	observers        []Observer
+	stopOnForcedDraw bool
+	teach            bool
+	scoreboard       *Scoreboard
+	renderer         Renderer
+	logger           *slog.Logger
 }
 
 // Start will start a game.
 func (g *Game) Start() {
-	fmt.Println("___Welcome to Tic Tac Toe in Go___")
-	for !g.isOver() {
-		g.printInfo()
-		i, j, err := g.current.GetMove(g.board)
+	if _, err := g.Play(); err != nil && !errors.Is(err, ErrInputClosed) {
+		fmt.Fprintln(g.out, "Game stopped:", err)
+	}
+}
 
+// Play alternates turns between the players until the game is over. It returns the winner, or nil
+// if the game ends in a draw. An illegal move is rejected and the same player is asked again,
+// unless the mark of the player is not one of the marks of the board, which stops the game. A
+// player who returns ErrResigned or ErrMoveTimeout loses the game and the player who would have
+// moved next wins, any other error from a player stops the game. A player who returns ErrUndo takes
+// back the last turn, see UndoLastTurn. A player whose input is closed stops the game with
+// ErrInputClosed.
+func (g *Game) Play() (Player, error) {
+	for {
+		done, winner, err := g.Step()
 		if err != nil {
-			fmt.Println("your input is invalid, please try again.")
-			continue
+			return nil, err
 		}
 
-		if g.board[i][j] != "_" {
-			fmt.Println("position is already occupied, please try again")
-			continue
+		if done {
+			return winner, nil
 		}
+	}
+}
+
+// Step plays a single turn of the game, so that a caller such as the event loop of a user
+// interface can drive the game. It asks the current player for a move, places it and passes the
+// turn to the next player. It reports whether the game is over and, if it is, the winner, which is
+// nil in a draw. An illegal move is rejected and the same player is asked again on the next step.
+// Errors are handled as in Play. Stepping a game that is over returns the same result again.
+func (g *Game) Step() (done bool, winner Player, err error) {
+	if g.over {
+		return true, g.winner, nil
+	}
+
+	if !g.started {
+		g.started = true
+		fmt.Fprintln(g.out, "___Welcome to Tic Tac Toe in Go___")
+		g.logStart()
+	}
+
+	if g.isOver() {
+		return true, g.finish(g.playerByMark(g.board.Winner())), nil
+	}
+
+	g.printInfo()
+	i, j, err := g.currentPlayer().GetMove(g.board)
+	if errors.Is(err, ErrResigned) {
+		fmt.Fprintln(g.out, g.currentPlayer().Name(), "resigns.")
+		g.switchPlayer()
+		return true, g.finish(g.currentPlayer()), nil
+	}
 
-		g.board[i][j] = g.current.Mark()
+	if errors.Is(err, ErrMoveTimeout) {
+		fmt.Fprintln(g.out, g.currentPlayer().Name(), "ran out of time.")
 		g.switchPlayer()
-		g.round++
+		return true, g.finish(g.currentPlayer()), nil
+	}
+
+	if errors.Is(err, ErrUndo) {
+		if err := g.UndoLastTurn(); err != nil {
+			fmt.Fprintf(g.out, "%v, please try again\n", err)
+		}
+		return false, nil, nil
+	}
+
+	if errors.Is(err, ErrInputClosed) {
+		fmt.Fprintln(g.out, "Input closed, the game is stopped.")
+		return false, nil, err
 	}
 
-	fmt.Println(g.board)
-	fmt.Println("Game over!")
+	if err != nil {
+		return false, nil, err
+	}
+
+	g.warnBlunder(g.currentPlayer(), i, j)
+	if err := g.board.PlaceMark(i, j, g.currentPlayer().Mark()); err != nil {
+		// Asking again cannot fix a mark that is not in the game.
+		if errors.Is(err, ErrIllegalMove) && g.board.isMark(g.currentPlayer().Mark()) {
+			fmt.Fprintf(g.out, "%v, please try again\n", err)
+			return false, nil, nil
+		}
+
+		return false, nil, err
+	}
+
+	g.notifyMove(g.currentPlayer(), i, j)
+	g.logMove(g.currentPlayer(), i, j)
+	mover := g.currentPlayer()
+	g.switchPlayer()
+	g.round++
+
+	// Only the move just made can have won the game.
+	if g.board.WinnerAfter(i, j) != "" {
+		return true, g.finish(mover), nil
+	}
+
+	if g.isOver() {
+		return true, g.finish(nil), nil
+	}
+
+	return false, nil, nil
+}
+
+// UndoLastTurn takes back the moves made since the last move of a human player, including that
+// move, so that the human player is to move again. In a game between a human and a computer player
+// it undoes the move of the computer player and the move of the human player before it, in a game
+// between human players only the last move. In a game without human players it undoes the last
+// move. It returns an error if the game is over or there is no move to undo.
+func (g *Game) UndoLastTurn() error {
+	if g.over {
+		return errors.New("the game is over")
+	}
+
+	history := g.board.history
+	if len(history) == 0 {
+		return errors.New("there is no move to undo")
+	}
+
+	n := 1
+	if g.hasHuman() {
+		n = 0
+		for k := len(history) - 1; k >= 0; k-- {
+			if _, ok := g.playerByMark(history[k].Mark).(*HumanPlayer); ok {
+				n = len(history) - k
+				break
+			}
+		}
+
+		if n == 0 {
+			return errors.New("there is no move of a human player to undo")
+		}
+	}
+
+	for ; n > 0; n-- {
+		if err := g.board.Undo(); err != nil {
+			return err
+		}
+
+		// A game can start on a board that already has moves.
+		if g.round > 1 {
+			g.round--
+		}
+	}
+
+	for k, p := range g.players {
+		if p.Mark() == g.board.CurrentMark() {
+			g.current = k
+			break
+		}
+	}
+
+	return nil
+}
+
+// hasHuman checks if one of the players is a human player.
+func (g *Game) hasHuman() bool {
+	for _, p := range g.players {
+		if _, ok := p.(*HumanPlayer); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WinnerPlayer returns the player whose mark has won on the board, or nil if the game is a draw or
+// still going on. A player who wins because the opponent resigned is returned as well.
+func (g *Game) WinnerPlayer() Player {
+	if g.over {
+		return g.winner
+	}
+
+	return g.playerByMark(g.board.Winner())
+}
+
+// finish prints the final board and the result, records it and notifies the observers. The winner
+// is nil if the game ends in a draw. It returns the winner.
+func (g *Game) finish(winner Player) Player {
+	fmt.Fprintln(g.out, g.render())
+	if winner == nil {
+		fmt.Fprintln(g.out, "Game over! It's a draw.")
+	} else {
+		fmt.Fprintln(g.out, "Game over!", winner.Name(), "wins.")
+	}
+
+	g.over, g.winner = true, winner
+	g.recordResult(winner)
+	g.notifyGameOver(winner)
+	g.logGameOver(winner)
+	return winner
+}
+
+// recordResult records the result of the game on the scoreboard, if the game has one. The winner
+// is nil if the game ended in a draw.
+func (g *Game) recordResult(winner Player) {
+	if g.scoreboard == nil {
+		return
+	}
+
+	result := Result{Players: make([]string, len(g.players))}
+	for k, p := range g.players {
+		result.Players[k] = p.Name()
+	}
+
+	if winner != nil {
+		result.Winner = winner.Name()
+	}
+
+	g.scoreboard.Record(result)
 }
 
-// IsOver checks if a game is over.
+// IsOver checks if a game is over. A game where no line can be completed anymore is over as a
+// draw.
 func (g *Game) isOver() bool {
-	return g.board.winner() != "" || g.board.emptyCount() == 0
+	if g.board.IsOver() || len(g.board.PossibleWinners()) == 0 {
+		return true
+	}
+
+	return g.stopOnForcedDraw && g.board.IsForcedDraw(g.currentPlayer().Mark())
 }
 
 func (g *Game) printInfo() {
-	fmt.Println("Turn #" + strconv.Itoa(g.round))
-	fmt.Println(g.board)
-	fmt.Println("Current player:", g.current.Name())
+	fmt.Fprintln(g.out, "Turn #"+strconv.Itoa(g.round))
+	fmt.Fprintln(g.out, g.render())
+	fmt.Fprintln(g.out, "Current player:", g.currentPlayer().Name())
+}
+
+func (g *Game) render() string {
+	if g.renderer == nil {
+		return g.board.String()
+	}
+
+	return g.renderer.Render(g.board)
+}
+
+func (g *Game) currentPlayer() Player {
+	return g.players[g.current]
 }
 
 func (g *Game) switchPlayer() {
-	if g.current == g.p1 {
-		g.current = g.p2
-	} else {
-		g.current = g.p1
+	g.current = (g.current + 1) % len(g.players)
+}
+
+func (g *Game) playerByMark(mark string) Player {
+	for _, p := range g.players {
+		if p.Mark() == mark {
+			return p
+		}
 	}
+
+	return nil
 }
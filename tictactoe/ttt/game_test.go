@@ -0,0 +1,485 @@
+package ttt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedPlayer plays a fixed sequence of moves.
+type scriptedPlayer struct {
+	name  string
+	mark  string
+	moves [][2]int
+}
+
+func (sp *scriptedPlayer) GetMove(b *Board) (int, int, error) {
+	if len(sp.moves) == 0 {
+		return 0, 0, errors.New("out of moves")
+	}
+
+	move := sp.moves[0]
+	sp.moves = sp.moves[1:]
+	return move[0], move[1], nil
+}
+
+func (sp *scriptedPlayer) Mark() string {
+	return sp.mark
+}
+
+func (sp *scriptedPlayer) Name() string {
+	return sp.name
+}
+
+// mustNewGame creates a game between two players and fails the test if the players cannot play
+// each other.
+func mustNewGame(t *testing.T, p1 Player, p2 Player, opts ...GameOption) *Game {
+	t.Helper()
+	return mustNewMultiplayerGame(t, []Player{p1, p2}, opts...)
+}
+
+// mustNewMultiplayerGame creates a game between the players and fails the test if they cannot play
+// each other.
+func mustNewMultiplayerGame(t *testing.T, players []Player, opts ...GameOption) *Game {
+	t.Helper()
+	g, err := NewMultiplayerGame(players, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return g
+}
+
+func TestGamePlay(t *testing.T) {
+	t.Run("Win", func(t *testing.T) {
+		p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {1, 1}, {2, 2}}}
+		p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {0, 2}}}
+
+		out := &bytes.Buffer{}
+		winner, err := mustNewGame(t, p1, p2, WithOutput(out)).Play()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winner != p1 {
+			t.Errorf("winner %v != Alice", winner)
+		}
+
+		if !bytes.Contains(out.Bytes(), []byte("Alice wins")) {
+			t.Errorf("output should announce the winner\n%s", out)
+		}
+	})
+
+	t.Run("IllegalMoveIsRetried", func(t *testing.T) {
+		p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {0, 1}, {0, 2}}}
+		p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}, {1, 0}, {1, 1}}}
+
+		winner, err := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{})).Play()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winner != p1 {
+			t.Errorf("winner %v != Alice", winner)
+		}
+	})
+
+	t.Run("Draw", func(t *testing.T) {
+		p1 := &scriptedPlayer{name: "Alice", mark: "X",
+			moves: [][2]int{{0, 0}, {0, 2}, {1, 0}, {2, 1}, {2, 2}}}
+		p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {1, 1}, {1, 2}, {2, 0}}}
+
+		winner, err := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{})).Play()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winner != nil {
+			t.Errorf("winner %v != no winner", winner)
+		}
+	})
+
+	t.Run("PlayerError", func(t *testing.T) {
+		p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}}}
+		p2 := &scriptedPlayer{name: "Bob", mark: "O"}
+
+		if _, err := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{})).Play(); err == nil {
+			t.Error("expected error when a player runs out of moves")
+		}
+	})
+}
+
+func TestGameStep(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {1, 1}, {1, 1}, {2, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {0, 2}}}
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}))
+
+	// Alice repeats (1, 1) once, which takes a step without a move.
+	steps := 0
+	for {
+		done, winner, err := g.Step()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		steps++
+
+		if !done {
+			if winner != nil {
+				t.Errorf("winner %v should be nil before the game is over", winner)
+			}
+			continue
+		}
+
+		if winner != p1 {
+			t.Errorf("winner %v != Alice", winner)
+		}
+		break
+	}
+
+	if steps != 6 {
+		t.Errorf("actual %d steps != expected 6 steps", steps)
+	}
+
+	if n := len(g.board.History()); n != 5 {
+		t.Errorf("actual %d moves != expected 5 moves", n)
+	}
+
+	if done, winner, err := g.Step(); !done || winner != p1 || err != nil {
+		t.Errorf("step after the game is over returned (%v, %v, %v)", done, winner, err)
+	}
+}
+
+func TestWinnerPlayer(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 1}, {1, 1}, {2, 1}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}, {2, 2}}}
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}))
+
+	for done := false; !done; {
+		if w := g.WinnerPlayer(); w != nil {
+			t.Errorf("winner player %v should be nil while the game is going on", w)
+		}
+
+		var err error
+		if done, _, err = g.Step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if w := g.WinnerPlayer(); w != p1 {
+		t.Errorf("winner player %v != Alice", w)
+	}
+
+	draw := mustNewGame(t, &scriptedPlayer{name: "Alice", mark: "X"},
+		&scriptedPlayer{name: "Bob", mark: "O"}, WithBoard(boardOf(
+			[]string{"X", "O", "X"},
+			[]string{"X", "O", "O"},
+			[]string{"O", "X", "X"},
+		)), WithOutput(&bytes.Buffer{}))
+	if _, err := draw.Play(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if w := draw.WinnerPlayer(); w != nil {
+		t.Errorf("winner player %v != nil in a draw", w)
+	}
+}
+
+func TestMultiplayerGame(t *testing.T) {
+	b, _ := NewBoardWithWin(5, 3)
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {0, 2}, {2, 4}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{4, 4}, {4, 2}, {0, 4}}}
+	p3 := &scriptedPlayer{name: "Carol", mark: "△", moves: [][2]int{{2, 0}, {2, 1}, {2, 2}}}
+
+	ro := &recordingObserver{}
+	g := mustNewMultiplayerGame(t, []Player{p1, p2, p3}, WithBoard(b), WithOutput(&bytes.Buffer{}))
+	g.AddObserver(ro)
+	winner, err := g.Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != p3 {
+		t.Errorf("winner %v != Carol", winner)
+	}
+
+	expected := []string{"Alice", "Bob", "Carol", "Alice", "Bob", "Carol", "Alice", "Bob", "Carol"}
+	history := b.History()
+	if len(history) != len(expected) {
+		t.Fatalf("history %v should have %d moves", history, len(expected))
+	}
+
+	for k, name := range expected {
+		if ro.events[k] != fmt.Sprintf("move %s %d %d", name, history[k].I, history[k].J) {
+			t.Errorf("move %d was played by %q, expected %s", k, ro.events[k], name)
+		}
+	}
+
+	if b.Opponent("O") != "△" || b.Opponent("△") != "X" {
+		t.Errorf("board marks %v should follow the order of the players", b.Marks())
+	}
+}
+
+func TestGameWithForcedDrawDetection(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "_"},
+		[]string{"_", "X", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	// Alice could win with a fork on (1, 0), but she lets the game slip into a draw instead.
+	p1 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{2, 2}, {2, 0}}}
+	p2 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 2}, {2, 1}}}
+
+	g := mustNewGame(t, p1, p2, WithBoard(b), WithOutput(&bytes.Buffer{}), WithForcedDrawDetection())
+	winner, err := g.Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != nil {
+		t.Errorf("winner %v != no winner", winner)
+	}
+
+	if n := len(b.History()); n != 5 {
+		t.Errorf("game should stop after 5 moves, %d moves were played", n)
+	}
+}
+
+func TestGameStopsOnDeadDraw(t *testing.T) {
+	// After eight moves no line can be completed, X has no move left for the last position.
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {0, 2}, {2, 1}, {1, 0}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {1, 1}, {1, 2}, {2, 0}}}
+
+	winner, err := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{})).Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != nil {
+		t.Errorf("winner %v should be nil in a dead draw", winner)
+	}
+}
+
+func TestGameMoveTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	hp := NewHumanPlayerWithIO("Calvin", "X", r, &bytes.Buffer{})
+	hp.SetMoveTimeout(10 * time.Millisecond)
+	cp := &scriptedPlayer{name: "Bob", mark: "O"}
+
+	out := &bytes.Buffer{}
+	winner, err := mustNewGame(t, hp, cp, WithOutput(out)).Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != cp {
+		t.Errorf("winner %v != Bob", winner)
+	}
+
+	if !strings.Contains(out.String(), "Calvin ran out of time.") {
+		t.Errorf("output should announce the forfeit\n%s", out)
+	}
+}
+
+func TestUndoLastTurn(t *testing.T) {
+	hp := NewHumanPlayer("Calvin", "X")
+	hp.in = strings.NewReader("1 1\nundo\n")
+	hp.out = &bytes.Buffer{}
+	cp := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}}}
+
+	g := mustNewGame(t, hp, cp, WithOutput(&bytes.Buffer{}))
+	if err := g.UndoLastTurn(); err == nil {
+		t.Error("undo before the first move should return an error")
+	}
+
+	for k := 0; k < 2; k++ {
+		if _, _, err := g.Step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Typing undo takes back the move of Bob and the move of Calvin before it.
+	if _, _, err := g.Step(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !g.board.Equal(NewBoard(3)) {
+		t.Errorf("board should be empty after the undo\n%s", g.board)
+	}
+
+	if g.currentPlayer() != hp {
+		t.Errorf("current player %s != Calvin", g.currentPlayer().Name())
+	}
+
+	if g.round != 1 {
+		t.Errorf("round %d != 1", g.round)
+	}
+
+	// Without human players only the last move is taken back.
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{1, 1}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}}}
+	g = mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}))
+	for k := 0; k < 2; k++ {
+		if _, _, err := g.Step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := g.UndoLastTurn(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if g.board.grid[1][1] != "X" || g.board.grid[0][0] != empty {
+		t.Errorf("only the move of Bob should be undone\n%s", g.board)
+	}
+
+	if g.currentPlayer() != p2 {
+		t.Errorf("current player %s != Bob", g.currentPlayer().Name())
+	}
+}
+
+func TestGameResign(t *testing.T) {
+	hp := NewHumanPlayer("Calvin", "X")
+	hp.in = strings.NewReader("1 1\nresign\n")
+	hp.out = &bytes.Buffer{}
+	cp := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}}}
+
+	out := &bytes.Buffer{}
+	s := NewScoreboard()
+	winner, err := mustNewGame(t, hp, cp, WithOutput(out), WithScoreboard(s)).Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != cp {
+		t.Errorf("winner %v != Bob", winner)
+	}
+
+	if !strings.Contains(out.String(), "Calvin resigns.") {
+		t.Errorf("output should announce the resignation\n%s", out)
+	}
+
+	if tally := s.Tally("Calvin"); tally.Losses != 1 {
+		t.Errorf("losses of Calvin %d != 1", tally.Losses)
+	}
+}
+
+func TestGameInputClosed(t *testing.T) {
+	hp := NewHumanPlayerWithIO("Calvin", "X", strings.NewReader("1 1\n"), &bytes.Buffer{})
+	cp := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}}}
+
+	out := &bytes.Buffer{}
+	if _, err := mustNewGame(t, hp, cp, WithOutput(out)).Play(); !errors.Is(err, ErrInputClosed) {
+		t.Fatalf("error %v is not ErrInputClosed", err)
+	}
+
+	if !strings.Contains(out.String(), "Input closed") {
+		t.Errorf("output should announce the closed input\n%s", out)
+	}
+}
+
+func TestGamePlayInvalidMark(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {1, 1}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "Q", moves: [][2]int{{0, 1}, {0, 2}}}
+
+	// The marks of the board are set back to X and O after the game is created, so Q is not one
+	// of them.
+	b := NewBoard(3)
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}), WithBoard(b))
+	b.SetMarks("X", "O")
+
+	if _, err := g.Play(); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("error %v is not ErrIllegalMove", err)
+	}
+}
+
+func TestGameStartsWithCurrentMark(t *testing.T) {
+	b := NewBoard(3)
+	b.SetStartingMark("O")
+
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 1}, {0, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}, {1, 1}, {2, 2}}}
+	winner, err := mustNewGame(t, p1, p2, WithBoard(b), WithOutput(&bytes.Buffer{})).Play()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != p2 {
+		t.Errorf("winner %v != Bob who moves first", winner)
+	}
+}
+
+func TestGameWithFirstPlayer(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 1}, {0, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 0}, {1, 1}, {2, 2}}}
+	g := mustNewGame(t, p1, p2, WithFirstPlayer(1), WithOutput(&bytes.Buffer{}))
+	if _, err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first := g.board.History()[0]; first.Mark != "O" {
+		t.Errorf("first move %v should carry the mark of Bob", first)
+	}
+
+	if _, err := NewGame(p1, p2, WithFirstPlayer(2)); err == nil {
+		t.Error("expected error when the first player is out of range")
+	}
+
+	b := NewBoard(3)
+	b.PlaceMark(1, 1, "X")
+	if _, err := NewGame(p1, p2, WithBoard(b), WithFirstPlayer(0)); err == nil {
+		t.Error("expected error when the board already has moves")
+	}
+}
+
+func TestNewGameInvalidMarks(t *testing.T) {
+	b := NewBoard(3)
+	b.PlaceMark(1, 1, "X")
+
+	testCases := []struct {
+		name     string
+		p1       Player
+		p2       Player
+		opts     []GameOption
+		expected string
+	}{
+		{
+			name:     "DuplicateMark",
+			p1:       &scriptedPlayer{name: "Alice", mark: "X"},
+			p2:       &scriptedPlayer{name: "Bob", mark: "X"},
+			expected: `players Alice and Bob share mark "X"`,
+		},
+		{
+			name:     "EmptyMark",
+			p1:       &scriptedPlayer{name: "Alice", mark: "X"},
+			p2:       &scriptedPlayer{name: "Bob", mark: ""},
+			expected: "player Bob has no mark",
+		},
+		{
+			name:     "MarkNotOnBoard",
+			p1:       &scriptedPlayer{name: "Alice", mark: "X"},
+			p2:       &scriptedPlayer{name: "Bob", mark: "Q"},
+			opts:     []GameOption{WithBoard(b)},
+			expected: `mark "Q" of player Bob is not one of the marks [X O] of the board`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewGame(tc.p1, tc.p2, tc.opts...)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if err.Error() != tc.expected {
+				t.Errorf("error %q != %q", err, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package ttt
+
+// Hash returns a 64 bit Zobrist hash of the position, i.e. of the marks on the board and the mark
+// to move. Every mark on every position has a random value and the hash is the XOR of the values
+// of the occupied positions and of the mark to move. Equal boards share the same hash, including a
+// board and its copies, while boards that differ by a single position or by the mark to move hash
+// differently unless they collide, which is unlikely but possible. Use Key where collisions are not
+// acceptable.
+func (b *Board) Hash() uint64 {
+	var h uint64
+	for i := range b.grid {
+		for j, mark := range b.grid[i] {
+			if mark != empty {
+				h ^= zobristKey(i*b.cols+j, mark)
+			}
+		}
+	}
+
+	// The mark to move counts as a mark on an extra position past the last one.
+	return h ^ zobristKey(b.rows*b.cols, b.turn)
+}
+
+// zobristKey returns the random value of mark on the cell-th position of a board in row-major
+// order. Marks can be any string and boards any size, so instead of keeping a table of values the
+// value is drawn from the splitmix64 sequence at an index derived from the mark and the position.
+// It is the same value for every board and every run.
+func zobristKey(cell int, mark string) uint64 {
+	// FNV-1a of the mark.
+	index := uint64(14695981039346656037)
+	for k := 0; k < len(mark); k++ {
+		index ^= uint64(mark[k])
+		index *= 1099511628211
+	}
+
+	return splitmix64(index + uint64(cell)*0x9e3779b97f4a7c15)
+}
+
+// splitmix64 scrambles x into a value that is uniformly distributed over the 64 bits.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
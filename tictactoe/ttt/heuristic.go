@@ -0,0 +1,97 @@
+package ttt
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// NewHeuristicPlayer is a constructor for heuristic player. The source of randomness breaks ties
+// between equally good moves, it defaults to a shared source if r is nil.
+func NewHeuristicPlayer(n, m string, r *rand.Rand) *HeuristicPlayer {
+	return &HeuristicPlayer{
+		name:   n,
+		mark:   m,
+		random: r,
+	}
+}
+
+// HeuristicPlayer is an automated player that follows a fixed order of rules instead of searching
+// the game tree. It completes a line if it can, else blocks a line of the opponent, else takes the
+// center, else a corner, else any other position. It is fast on boards of any size but does not
+// always play optimally, e.g. it does not see forks coming.
+type HeuristicPlayer struct {
+	name   string
+	mark   string
+	random *rand.Rand
+}
+
+// GetMove returns next move.
+func (hp *HeuristicPlayer) GetMove(b *Board) (int, int, error) {
+	if b.IsOver() {
+		return 0, 0, errors.New("there is no available position")
+	}
+
+	rules := []func(*Board) []Position{
+		func(b *Board) []Position { return b.WinningMoves(hp.mark) },
+		func(b *Board) []Position { return b.BlockingMoves(hp.mark) },
+		centerMoves,
+		cornerMoves,
+		func(b *Board) []Position { return b.GetAvailablePos() },
+	}
+
+	for _, rule := range rules {
+		if moves := rule(b); len(moves) > 0 {
+			pos := moves[hp.rng().Intn(len(moves))]
+			return pos.Row, pos.Col, nil
+		}
+	}
+
+	return 0, 0, errors.New("there is no available position")
+}
+
+// Mark is a getter for player's mark.
+func (hp *HeuristicPlayer) Mark() string {
+	return hp.mark
+}
+
+// Name is a getter for player's name.
+func (hp *HeuristicPlayer) Name() string {
+	return hp.name
+}
+
+func (hp *HeuristicPlayer) rng() *rand.Rand {
+	if hp.random == nil {
+		return defaultRandom
+	}
+
+	return hp.random
+}
+
+// centerMoves returns the empty positions at the center of the board, one position on boards of odd
+// sides and up to four on boards of even sides.
+func centerMoves(b *Board) []Position {
+	closest := b.centerDistance(Position{Row: (b.rows - 1) / 2, Col: (b.cols - 1) / 2})
+
+	moves := []Position{}
+	for _, pos := range b.GetAvailablePos() {
+		if b.centerDistance(pos) == closest {
+			moves = append(moves, pos)
+		}
+	}
+
+	return moves
+}
+
+// cornerMoves returns the empty corners of the board.
+func cornerMoves(b *Board) []Position {
+	lastRow, lastCol := b.rows-1, b.cols-1
+
+	moves := []Position{}
+	for _, pos := range []Position{{0, 0}, {0, lastCol}, {lastRow, 0}, {lastRow, lastCol}} {
+		if b.IsLegalMove(pos.Row, pos.Col) {
+			moves = append(moves, pos)
+		}
+	}
+
+	return moves
+}
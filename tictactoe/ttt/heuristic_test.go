@@ -0,0 +1,121 @@
+package ttt
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHeuristicPlayer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mark     string
+		board    *Board
+		expected [][2]int
+	}{
+		{
+			name: "Win",
+			mark: "X",
+			board: boardOf(
+				[]string{"X", "X", "_"},
+				[]string{"O", "O", "_"},
+				[]string{"_", "_", "_"},
+			),
+			expected: [][2]int{{0, 2}},
+		},
+		{
+			name: "Block",
+			mark: "X",
+			board: boardOf(
+				[]string{"O", "O", "_"},
+				[]string{"_", "X", "_"},
+				[]string{"_", "_", "X"},
+			),
+			expected: [][2]int{{0, 2}},
+		},
+		{
+			name: "Center",
+			mark: "O",
+			board: boardOf(
+				[]string{"X", "_", "_"},
+				[]string{"_", "_", "_"},
+				[]string{"_", "_", "_"},
+			),
+			expected: [][2]int{{1, 1}},
+		},
+		{
+			name: "Corner",
+			mark: "O",
+			board: boardOf(
+				[]string{"_", "_", "_"},
+				[]string{"_", "X", "_"},
+				[]string{"_", "_", "_"},
+			),
+			expected: [][2]int{{0, 0}, {0, 2}, {2, 0}, {2, 2}},
+		},
+		{
+			name: "Edge",
+			mark: "X",
+			board: boardOf(
+				[]string{"X", "_", "_", "X"},
+				[]string{"_", "X", "O", "_"},
+				[]string{"_", "X", "O", "_"},
+				[]string{"O", "_", "_", "O"},
+			),
+			expected: [][2]int{{0, 1}, {0, 2}, {1, 0}, {1, 3}, {2, 0}, {2, 3}, {3, 1}, {3, 2}},
+		},
+		{
+			name:     "CenterOfEvenBoard",
+			mark:     "X",
+			board:    NewBoard(4),
+			expected: [][2]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hp := NewHeuristicPlayer("Hal", tc.mark, rand.New(rand.NewSource(42)))
+			i, j, err := hp.GetMove(tc.board)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, pos := range tc.expected {
+				if pos == [2]int{i, j} {
+					return
+				}
+			}
+
+			t.Errorf("move (%d, %d) is not one of %v\n%s", i, j, tc.expected, tc.board)
+		})
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		for n := 0; n < 10; n++ {
+			p1 := NewHeuristicPlayer("Hal", "O", rand.New(rand.NewSource(int64(n))))
+			p2 := NewHeuristicPlayer("Hal", "O", rand.New(rand.NewSource(int64(n))))
+
+			b := boardOf(
+				[]string{"_", "_", "_"},
+				[]string{"_", "X", "_"},
+				[]string{"_", "_", "_"},
+			)
+
+			i1, j1, _ := p1.GetMove(b)
+			i2, j2, _ := p2.GetMove(b)
+			if i1 != i2 || j1 != j2 {
+				t.Errorf("move (%d, %d) != move (%d, %d) with the same seed", i1, j1, i2, j2)
+			}
+		}
+	})
+
+	t.Run("GameOver", func(t *testing.T) {
+		hp := NewHeuristicPlayer("Hal", "X", nil)
+		if _, _, err := hp.GetMove(boardOf(
+			[]string{"X", "O", "X"},
+			[]string{"X", "O", "O"},
+			[]string{"O", "X", "X"},
+		)); err == nil {
+			t.Error("expected error when there is no available position")
+		}
+	})
+}
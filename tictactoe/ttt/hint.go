@@ -0,0 +1,18 @@
+package ttt
+
+// HintProvider suggests a move for a mark.
+type HintProvider interface {
+	Hint(b *Board, mark string) (int, int, error)
+}
+
+// NewMinimaxHintProvider returns a hint provider that suggests the optimal move found by minimax.
+func NewMinimaxHintProvider() HintProvider {
+	return minimaxHintProvider{}
+}
+
+type minimaxHintProvider struct{}
+
+// Hint returns the move a Hard computer player would make with mark.
+func (minimaxHintProvider) Hint(b *Board, mark string) (int, int, error) {
+	return NewComputerPlayer("Hint", mark, Hard).GetMove(b)
+}
@@ -1,12 +1,29 @@
 package ttt
 
-import "fmt"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
 
-// NewHumanPlayer is a constructor for human player.
+// NewHumanPlayer is a constructor for human player. It reads moves from standard input and prints
+// prompts to standard output.
 func NewHumanPlayer(n string, m string) *HumanPlayer {
+	return NewHumanPlayerWithIO(n, m, os.Stdin, os.Stdout)
+}
+
+// NewHumanPlayerWithIO is a constructor for human player that reads moves from in and prints
+// prompts to out. Players that share a *bufio.Reader as their input can take turns reading from
+// it.
+func NewHumanPlayerWithIO(n string, m string, in io.Reader, out io.Writer) *HumanPlayer {
 	return &HumanPlayer{
 		name: n,
 		mark: m,
+		in:   in,
+		out:  out,
 	}
 }
 
@@ -14,18 +31,143 @@ func NewHumanPlayer(n string, m string) *HumanPlayer {
 type HumanPlayer struct {
 	name string
 	mark string
+	in   io.Reader
+	out  io.Writer
+
+	// reader buffers in, it is created on the first read so that in can be replaced before. It is
+	// in itself if in is already buffered.
+	reader *bufio.Reader
+
+	// timeout is the time a move may take, zero means no limit. pending is the read of a line that
+	// was still waiting for input when the time ran out, it is nil if there is none.
+	timeout time.Duration
+	pending chan lineResult
+
+	hints HintProvider
+}
+
+// lineResult is a line read from the input, or the error that stopped the read.
+type lineResult struct {
+	line string
+	err  error
+}
+
+// SetMoveTimeout limits the time the player has to enter a legal move, e.g. so that a player who
+// walks away from a networked game does not hold it up forever. Once the time is up, GetMove
+// returns ErrMoveTimeout. A line that is entered after the time is up is read by the next call of
+// GetMove. Zero, the default, means no limit.
+func (p *HumanPlayer) SetMoveTimeout(d time.Duration) {
+	p.timeout = d
+}
+
+// SetHintProvider turns on hints. The suggested move is printed before every prompt. Setting it to
+// nil turns hints off.
+func (p *HumanPlayer) SetHintProvider(h HintProvider) {
+	p.hints = h
+}
+
+// GetMove returns next move. It keeps asking for a position until a legal one is entered. It
+// returns ErrResigned if the player types "resign", ErrUndo if the player types "undo",
+// ErrMoveTimeout if the time set by SetMoveTimeout runs out, ErrInputClosed if the input ends, and
+// any other error only when the input cannot be read.
+func (p *HumanPlayer) GetMove(b *Board) (int, int, error) {
+	if p.hints != nil {
+		if i, j, err := p.hints.Hint(b, p.mark); err == nil {
+			fmt.Fprintln(p.out, "Suggested move:", i, j)
+		}
+	}
+
+	// The time runs out at the same moment however many invalid lines are entered.
+	var deadline <-chan time.Time
+	if p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		fmt.Fprint(p.out, "Enter position (e.g. 1 1 or B2, undo or resign): ")
+		line, err := p.readLine(deadline)
+		if err == ErrMoveTimeout {
+			fmt.Fprintln(p.out)
+			return 0, 0, err
+		}
+
+		if err == io.EOF {
+			fmt.Fprintln(p.out)
+			return 0, 0, ErrInputClosed
+		}
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// The longer side bounds the parsed position, checkMove rejects the rest of a rectangular
+		// board.
+		i, j, err := ParseMove(line, max(b.Rows(), b.Cols()))
+		if err == ErrResigned || err == ErrUndo {
+			return 0, 0, err
+		}
+
+		if err != nil {
+			// The whole line is discarded, so a stray character cannot affect the next line.
+			fmt.Fprintf(p.out, "Invalid input %q: %v\n", line, err)
+			continue
+		}
+
+		if err := b.checkMove(i, j); err != nil {
+			fmt.Fprintln(p.out, err)
+			continue
+		}
+
+		fmt.Fprintln(p.out, "Your input:", i, j)
+		return i, j, nil
+	}
+}
+
+// readLine reads the next line of input. It returns ErrMoveTimeout if deadline fires first, a nil
+// deadline never fires. A read cannot be interrupted, so a read that is still waiting when the
+// deadline fires is kept pending and the next call waits for it.
+func (p *HumanPlayer) readLine(deadline <-chan time.Time) (string, error) {
+	if p.reader == nil {
+		if r, ok := p.in.(*bufio.Reader); ok {
+			p.reader = r
+		} else {
+			p.reader = bufio.NewReader(p.in)
+		}
+	}
+
+	if deadline == nil && p.pending == nil {
+		return readLine(p.reader)
+	}
+
+	if p.pending == nil {
+		pending := make(chan lineResult, 1)
+		go func() {
+			line, err := readLine(p.reader)
+			pending <- lineResult{line: line, err: err}
+		}()
+		p.pending = pending
+	}
+
+	select {
+	case r := <-p.pending:
+		p.pending = nil
+		return r.line, r.err
+	case <-deadline:
+		return "", ErrMoveTimeout
+	}
 }
 
-// GetMove returns next move.
-func (p *HumanPlayer) GetMove(b *board) (int, int, error) {
-	fmt.Print("Enter position: ")
-	var i, j int
-	if n, err := fmt.Scanf("%d %d", &i, &j); err != nil || n != 2 {
-		return 0, 0, err
+// readLine reads the next line of r without the line break. The last line does not need to end
+// with a line break, and io.EOF is only returned once there is nothing left.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
 	}
 
-	fmt.Println("Your input:", i, j)
-	return i, j, nil
+	return strings.TrimRight(line, "\r\n"), err
 }
 
 // Mark is a getter for player's mark.
@@ -0,0 +1,132 @@
+package ttt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHumanPlayerHint(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "X", "_"},
+		[]string{"O", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	out := &bytes.Buffer{}
+	hp := NewHumanPlayer("Calvin", "X")
+	hp.in = strings.NewReader("2 2\n")
+	hp.out = out
+	hp.SetHintProvider(NewMinimaxHintProvider())
+
+	i, j, err := hp.GetMove(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Suggested move: 0 2") {
+		t.Errorf("output should suggest the winning move (0, 2)\n%s", out)
+	}
+
+	if i != 2 || j != 2 {
+		t.Errorf("move (%d, %d) != entered move (2, 2)", i, j)
+	}
+}
+
+func TestHumanPlayerAlgebraicInput(t *testing.T) {
+	out := &bytes.Buffer{}
+	hp := NewHumanPlayer("Calvin", "X")
+	hp.in = strings.NewReader("Z9\nC3")
+	hp.out = out
+
+	i, j, err := hp.GetMove(NewBoard(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 2 || j != 2 {
+		t.Errorf("move (%d, %d) != entered move (2, 2)", i, j)
+	}
+
+	if !strings.Contains(out.String(), "out of range") {
+		t.Errorf("output should reject the out of range input\n%s", out)
+	}
+}
+
+func TestHumanPlayerWithIO(t *testing.T) {
+	out := &bytes.Buffer{}
+	hp := NewHumanPlayerWithIO("Calvin", "X", strings.NewReader("1 1\n"), out)
+
+	i, j, err := hp.GetMove(NewBoard(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 1 || j != 1 {
+		t.Errorf("move (%d, %d) != entered move (1, 1)", i, j)
+	}
+
+	if !strings.Contains(out.String(), "Enter position") {
+		t.Errorf("output should contain the prompt\n%s", out)
+	}
+}
+
+func TestHumanPlayerInputClosed(t *testing.T) {
+	hp := NewHumanPlayerWithIO("Calvin", "X", strings.NewReader(""), &bytes.Buffer{})
+	if _, _, err := hp.GetMove(NewBoard(3)); err != ErrInputClosed {
+		t.Errorf("error %v != %v", err, ErrInputClosed)
+	}
+
+	// Input that runs out after an invalid line is closed as well.
+	hp = NewHumanPlayerWithIO("Calvin", "X", strings.NewReader("9 9\n"), &bytes.Buffer{})
+	if _, _, err := hp.GetMove(NewBoard(3)); err != ErrInputClosed {
+		t.Errorf("error %v != %v", err, ErrInputClosed)
+	}
+}
+
+func TestHumanPlayerRejectsMalformedLine(t *testing.T) {
+	out := &bytes.Buffer{}
+	hp := NewHumanPlayerWithIO("Calvin", "X", strings.NewReader("oops\n1 1\n"), out)
+
+	i, j, err := hp.GetMove(NewBoard(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 1 || j != 1 {
+		t.Errorf("move (%d, %d) != entered move (1, 1)", i, j)
+	}
+
+	if count := strings.Count(out.String(), `Invalid input "oops"`); count != 1 {
+		t.Errorf("invalid inputs %d != 1\n%s", count, out)
+	}
+
+	if count := strings.Count(out.String(), "Enter position"); count != 2 {
+		t.Errorf("prompts %d != 2\n%s", count, out)
+	}
+}
+
+func TestHumanPlayerMoveTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	hp := NewHumanPlayerWithIO("Calvin", "X", r, &bytes.Buffer{})
+	hp.SetMoveTimeout(10 * time.Millisecond)
+	if _, _, err := hp.GetMove(NewBoard(3)); err != ErrMoveTimeout {
+		t.Fatalf("error %v != %v", err, ErrMoveTimeout)
+	}
+
+	// The line entered after the time ran out is the next move.
+	hp.SetMoveTimeout(0)
+	go w.Write([]byte("1 1\n"))
+	i, j, err := hp.GetMove(NewBoard(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if i != 1 || j != 1 {
+		t.Errorf("move (%d, %d) != entered move (1, 1)", i, j)
+	}
+}
@@ -0,0 +1,55 @@
+package ttt
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger logs the start of the game, every move and the result on l as structured records.
+// Nothing is logged by default.
+func WithLogger(l *slog.Logger) GameOption {
+	return func(g *Game) {
+		g.logger = l
+	}
+}
+
+func (g *Game) logStart() {
+	if g.logger == nil {
+		return
+	}
+
+	names := make([]string, len(g.players))
+	for k, p := range g.players {
+		names[k] = p.Name()
+	}
+
+	g.logger.LogAttrs(context.Background(), slog.LevelInfo, "game start",
+		slog.Any("players", names), slog.Int("size", g.board.Size()))
+}
+
+func (g *Game) logMove(p Player, i, j int) {
+	if g.logger == nil {
+		return
+	}
+
+	g.logger.LogAttrs(context.Background(), slog.LevelInfo, "move",
+		slog.String("player", p.Name()), slog.String("mark", p.Mark()), slog.Int("i", i),
+		slog.Int("j", j), slog.Int("round", g.round))
+}
+
+// logGameOver logs the result of the game. The winner is nil if the game ended in a draw.
+func (g *Game) logGameOver(winner Player) {
+	if g.logger == nil {
+		return
+	}
+
+	if winner == nil {
+		g.logger.LogAttrs(context.Background(), slog.LevelInfo, "game over",
+			slog.String("result", "draw"), slog.Int("moves", len(g.board.history)))
+		return
+	}
+
+	g.logger.LogAttrs(context.Background(), slog.LevelInfo, "game over",
+		slog.String("result", "win"), slog.String("winner", winner.Name()),
+		slog.Int("moves", len(g.board.history)))
+}
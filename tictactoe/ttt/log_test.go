@@ -0,0 +1,70 @@
+package ttt
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// recordHandler keeps every record it handles.
+type recordHandler struct {
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func TestGameWithLogger(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {0, 1}, {0, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{1, 0}, {1, 1}}}
+
+	h := &recordHandler{}
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}), WithLogger(slog.New(h)))
+	if _, err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"game start", "move", "move", "move", "move", "move", "game over"}
+	if len(h.records) != len(expected) {
+		t.Fatalf("actual %d records != expected %d records", len(h.records), len(expected))
+	}
+
+	for k, r := range h.records {
+		if r.Message != expected[k] {
+			t.Errorf("record %d message %q != %q", k, r.Message, expected[k])
+		}
+	}
+
+	attrs := make(map[string]string)
+	h.records[1].Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.String()
+		return true
+	})
+	if attrs["player"] != "Alice" || attrs["i"] != "0" || attrs["j"] != "0" {
+		t.Errorf("attributes %v of the first move != player Alice at (0, 0)", attrs)
+	}
+
+	attrs = make(map[string]string)
+	h.records[len(h.records)-1].Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.String()
+		return true
+	})
+	if attrs["result"] != "win" || attrs["winner"] != "Alice" {
+		t.Errorf("attributes %v of the game over != Alice wins", attrs)
+	}
+}
@@ -0,0 +1,105 @@
+package ttt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// NewMatch is a constructor for a match between two players. The options configure every game of
+// the match, and every game starts on a copy of the board they set. It returns an error if the
+// players cannot play each other, see NewGame.
+func NewMatch(p1 Player, p2 Player, opts ...GameOption) (*Match, error) {
+	g, err := NewGame(p1, p2, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Match{
+		players:    [2]Player{p1, p2},
+		opts:       opts,
+		board:      g.board.Copy(),
+		out:        g.out,
+		scoreboard: NewScoreboard(),
+	}, nil
+}
+
+// Match is a series of games between two players who take turns going first.
+type Match struct {
+	players    [2]Player
+	opts       []GameOption
+	board      *Board
+	out        io.Writer
+	scoreboard *Scoreboard
+}
+
+// Scoreboard returns the scoreboard that the results of the games of the match are recorded on.
+func (m *Match) Scoreboard() *Scoreboard {
+	return m.scoreboard
+}
+
+// PlayBestOf plays up to n games, the first player of the match goes first in odd games and the
+// second player in even games. The match ends as soon as a player has won more games than the
+// other player can still catch up on. It returns the player with the most wins, or nil if both
+// players won the same number of games.
+func (m *Match) PlayBestOf(n int) (Player, error) {
+	if n < 1 {
+		return nil, errors.New("a match needs at least one game")
+	}
+
+	for round := 0; round < n; round++ {
+		first, second := m.players[round%2], m.players[(round+1)%2]
+		fmt.Fprintf(m.out, "Game %d of %d, %s goes first\n", round+1, n, first.Name())
+
+		opts := append(m.opts[:len(m.opts):len(m.opts)], WithBoard(m.board.Copy()),
+			WithScoreboard(m.scoreboard))
+		g, err := NewGame(first, second, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := g.Play(); err != nil {
+			return nil, err
+		}
+
+		if m.lead() > n-round-1 {
+			break
+		}
+	}
+
+	fmt.Fprintln(m.out, m.scoreboard.Summary())
+	winner := m.leader()
+	if winner == nil {
+		fmt.Fprintln(m.out, "Match over! It's a draw.")
+		return nil, nil
+	}
+
+	fmt.Fprintln(m.out, "Match over!", winner.Name(), "wins the match.")
+	return winner, nil
+}
+
+// lead returns by how many wins the leading player is ahead.
+func (m *Match) lead() int {
+	wins1 := m.scoreboard.Tally(m.players[0].Name()).Wins
+	wins2 := m.scoreboard.Tally(m.players[1].Name()).Wins
+	if lead := wins1 - wins2; lead > 0 {
+		return lead
+	}
+
+	return wins2 - wins1
+}
+
+// leader returns the player with the most wins, or nil if both players won the same number of
+// games.
+func (m *Match) leader() Player {
+	wins1 := m.scoreboard.Tally(m.players[0].Name()).Wins
+	wins2 := m.scoreboard.Tally(m.players[1].Name()).Wins
+	switch {
+	case wins1 > wins2:
+		return m.players[0]
+	case wins2 > wins1:
+		return m.players[1]
+	default:
+		return nil
+	}
+}
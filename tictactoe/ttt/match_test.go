@@ -0,0 +1,77 @@
+package ttt
+
+import (
+	"bytes"
+	"testing"
+)
+
+// mustNewMatch creates a match between two players and fails the test if the players cannot play
+// each other.
+func mustNewMatch(t *testing.T, p1 Player, p2 Player, opts ...GameOption) *Match {
+	t.Helper()
+	m, err := NewMatch(p1, p2, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return m
+}
+
+func TestMatchPlayBestOf(t *testing.T) {
+	// Alice plays the top row and Bob the middle row, whoever goes first wins. Alice goes first in
+	// the first and the third game.
+	alice := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{
+		{0, 0}, {0, 1}, {0, 2},
+		{0, 0}, {0, 1},
+		{0, 0}, {0, 1}, {0, 2},
+	}}
+	bob := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{
+		{1, 0}, {1, 1},
+		{1, 0}, {1, 1}, {1, 2},
+		{1, 0}, {1, 1},
+	}}
+
+	m := mustNewMatch(t, alice, bob, WithOutput(&bytes.Buffer{}))
+	winner, err := m.PlayBestOf(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != alice {
+		t.Errorf("match winner %v != Alice", winner)
+	}
+
+	if tally := m.Scoreboard().Tally("Alice"); tally != (Tally{Wins: 2, Losses: 1}) {
+		t.Errorf("tally of Alice %+v != 2 wins and 1 loss", tally)
+	}
+
+	if len(alice.moves) != 0 || len(bob.moves) != 0 {
+		t.Errorf("players have moves left: Alice %v, Bob %v", alice.moves, bob.moves)
+	}
+}
+
+func TestMatchStopsOnceDecided(t *testing.T) {
+	// Alice wins the first two games so the third is not played.
+	alice := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{
+		{0, 0}, {0, 1}, {0, 2},
+		{0, 0}, {0, 1}, {0, 2},
+	}}
+	bob := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{
+		{1, 0}, {1, 1},
+		{2, 0}, {1, 1}, {2, 2},
+	}}
+
+	m := mustNewMatch(t, alice, bob, WithOutput(&bytes.Buffer{}))
+	winner, err := m.PlayBestOf(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != alice {
+		t.Errorf("match winner %v != Alice", winner)
+	}
+
+	if tally := m.Scoreboard().Tally("Bob"); tally.Losses != 2 {
+		t.Errorf("losses of Bob %d != 2", tally.Losses)
+	}
+}
@@ -0,0 +1,58 @@
+package ttt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RunMenu asks for the board size, the type of the opponent and, for a computer opponent, its
+// difficulty, and then creates the game. An invalid selection is asked again. The human players
+// read their moves from in as well, and the game is printed to out, in color if out is a
+// terminal. It returns an error if in is closed before every option is selected.
+func RunMenu(in io.Reader, out io.Writer) (*Game, error) {
+	r := bufio.NewReader(in)
+
+	size, err := promptChoice(r, out, fmt.Sprintf("Board size (%d-%d): ", MinGameSize, MaxGameSize),
+		MinGameSize, MaxGameSize)
+	if err != nil {
+		return nil, err
+	}
+
+	opponent, err := promptChoice(r, out, "Opponent (1) human (2) random (3) computer: ", 1, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := GameConfig{Size: size, Opponent: Opponent(opponent - 1)}
+	if cfg.Opponent == ComputerOpponent {
+		d, err := promptChoice(r, out, "Difficulty (1) easy (2) medium (3) hard: ", 1, 3)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Difficulty = Difficulty(d - 1)
+	}
+
+	return cfg.NewGame(r, out)
+}
+
+// promptChoice asks for a number between min and max until a valid one is entered.
+func promptChoice(r *bufio.Reader, out io.Writer, prompt string, min, max int) (int, error) {
+	for {
+		fmt.Fprint(out, prompt)
+		line, err := readLine(r)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || n < min || n > max {
+			fmt.Fprintf(out, "Invalid selection, please enter a number from %d to %d\n", min, max)
+			continue
+		}
+
+		return n, nil
+	}
+}
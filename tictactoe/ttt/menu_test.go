@@ -0,0 +1,58 @@
+package ttt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunMenu(t *testing.T) {
+	t.Run("ComputerOpponent", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		g, err := RunMenu(strings.NewReader("9\n4\nchess\n3\n3\n"), out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if g.board.Size() != 4 {
+			t.Errorf("board size %d != 4", g.board.Size())
+		}
+
+		cp, ok := g.players[1].(*ComputerPlayer)
+		if !ok {
+			t.Fatalf("opponent %T is not a computer player", g.players[1])
+		}
+
+		if cp.Difficulty() != Hard {
+			t.Errorf("difficulty %v != %v", cp.Difficulty(), Hard)
+		}
+
+		if count := strings.Count(out.String(), "Invalid selection"); count != 2 {
+			t.Errorf("invalid selections %d != 2\n%s", count, out)
+		}
+	})
+
+	t.Run("HumanOpponent", func(t *testing.T) {
+		// The moves after the menu are read by the human players.
+		in := strings.NewReader("3\n1\n0 0\n1 0\n0 1\n1 1\n0 2\n")
+		g, err := RunMenu(in, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		winner, err := g.Play()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winner == nil || winner.Mark() != "X" {
+			t.Errorf("winner %v != X", winner)
+		}
+	})
+
+	t.Run("ClosedInput", func(t *testing.T) {
+		if _, err := RunMenu(strings.NewReader("3\n"), &bytes.Buffer{}); err == nil {
+			t.Error("closed input should return an error")
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package ttt
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Move is a mark placed on position (I, J) of a board.
+type Move struct {
+	I    int    `json:"i"`
+	J    int    `json:"j"`
+	Mark string `json:"mark"`
+}
+
+// Position is a position of a board, Row and Col being zero based.
+type Position struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// ReplayMoves places the moves in order on an empty size by size board. It stops at the first
+// illegal move and returns an error carrying the index of that move. It returns an error if the
+// size is not positive.
+func ReplayMoves(size int, moves []Move) (*Board, error) {
+	b, err := NewBoardWithWin(size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.ApplyMoves(moves); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ReplayAnimated prints the empty board and then the board after each move, with a caption naming
+// the move, e.g. "Player X plays (1, 1)", and a pause of delay between the frames. The board is the
+// smallest square board, at least 3 by 3, that holds every move. It stops at the first illegal move
+// and returns an error carrying the index of that move.
+func ReplayAnimated(moves []Move, out io.Writer, delay time.Duration) error {
+	b := boardForMoves(moves)
+	fmt.Fprintf(out, "Start\n%s\n\n", b)
+
+	for k, m := range moves {
+		if err := b.PlaceMark(m.I, m.J, m.Mark); err != nil {
+			return fmt.Errorf("move %d: %w", k, err)
+		}
+
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		fmt.Fprintf(out, "Player %s plays (%d, %d)\n%s\n\n", m.Mark, m.I, m.J, b)
+	}
+
+	return nil
+}
+
+// resign is the input that concedes the game.
+const resign = "resign"
+
+// undo is the input that takes back the last turn.
+const undo = "undo"
+
+// ParseMove parses a position of a size by size board. It accepts a zero based row and column
+// separated by a space, e.g. "1 2", or algebraic notation made of a column letter and a one based
+// row number, e.g. "C2" or "c2". Both examples refer to position (1, 2). It returns ErrResigned if
+// the input is "resign", ErrUndo if the input is "undo", and an error if the input is in neither
+// form or the position is outside of the board.
+func ParseMove(s string, size int) (i, j int, err error) {
+	if strings.EqualFold(strings.TrimSpace(s), resign) {
+		return 0, 0, ErrResigned
+	}
+
+	if strings.EqualFold(strings.TrimSpace(s), undo) {
+		return 0, 0, ErrUndo
+	}
+
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 2:
+		if i, err = strconv.Atoi(fields[0]); err != nil {
+			return 0, 0, fmt.Errorf("row %q is not a number", fields[0])
+		}
+
+		if j, err = strconv.Atoi(fields[1]); err != nil {
+			return 0, 0, fmt.Errorf("column %q is not a number", fields[1])
+		}
+	case 1:
+		letter, n := utf8.DecodeRuneInString(fields[0])
+		number := fields[0][n:]
+		if !unicode.IsLetter(letter) || letter > unicode.MaxASCII {
+			return 0, 0, fmt.Errorf("column %q is not a letter", string(letter))
+		}
+		j = int(unicode.ToLower(letter) - 'a')
+
+		row, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, 0, fmt.Errorf("row %q is not a number", number)
+		}
+		i = row - 1
+
+		if j >= size {
+			return 0, 0, fmt.Errorf("column %q is out of range a-%c", string(letter), 'a'+size-1)
+		}
+
+		if i < 0 || i >= size {
+			return 0, 0, fmt.Errorf("row %d is out of range 1-%d", row, size)
+		}
+	default:
+		return 0, 0, fmt.Errorf("position %q should be a row and a column or a letter and a number",
+			s)
+	}
+
+	if i < 0 || i >= size || j < 0 || j >= size {
+		return 0, 0, fmt.Errorf("position (%d, %d) is out of range 0-%d", i, j, size-1)
+	}
+
+	return i, j, nil
+}
@@ -0,0 +1,126 @@
+package ttt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplayMoves(t *testing.T) {
+	t.Run("ValidMoves", func(t *testing.T) {
+		moves := []Move{
+			{I: 1, J: 1, Mark: "X"},
+			{I: 0, J: 0, Mark: "O"},
+			{I: 2, J: 1, Mark: "X"},
+		}
+
+		b, err := ReplayMoves(3, moves)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := boardOf(
+			[]string{"O", "_", "_"},
+			[]string{"_", "X", "_"},
+			[]string{"_", "X", "_"},
+		)
+		if !b.Equal(expected) {
+			t.Errorf("replayed board\n%s\n!= expected board\n%s", b, expected)
+		}
+
+		if len(b.History()) != len(moves) {
+			t.Errorf("history %v != %v", b.History(), moves)
+		}
+	})
+
+	t.Run("OccupiedCell", func(t *testing.T) {
+		moves := []Move{
+			{I: 1, J: 1, Mark: "X"},
+			{I: 0, J: 0, Mark: "O"},
+			{I: 1, J: 1, Mark: "X"},
+		}
+
+		_, err := ReplayMoves(3, moves)
+		if !errors.Is(err, ErrIllegalMove) {
+			t.Fatalf("error %v is not ErrIllegalMove", err)
+		}
+
+		if !strings.Contains(err.Error(), "move 2") {
+			t.Errorf("error %q should contain the index of the illegal move", err)
+		}
+	})
+
+	t.Run("InvalidSize", func(t *testing.T) {
+		for _, moves := range [][]Move{nil, {{I: 0, J: 0, Mark: "X"}}} {
+			if b, err := ReplayMoves(0, moves); err == nil {
+				t.Errorf("replaying %v on a board of size 0 should return an error, got\n%s", moves,
+					b)
+			}
+		}
+	})
+}
+
+func TestReplayAnimated(t *testing.T) {
+	moves := []Move{
+		{I: 1, J: 1, Mark: "X"},
+		{I: 0, J: 0, Mark: "O"},
+		{I: 2, J: 1, Mark: "X"},
+	}
+
+	out := &bytes.Buffer{}
+	if err := ReplayAnimated(moves, out, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every frame of a 3 by 3 board has two row separators.
+	if frames := strings.Count(out.String(), "---+---+---") / 2; frames != len(moves)+1 {
+		t.Errorf("actual %d frames != expected %d frames", frames, len(moves)+1)
+	}
+
+	if !strings.Contains(out.String(), "Player O plays (0, 0)") {
+		t.Errorf("output should contain the caption of every move\n%s", out)
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected [2]int
+	}{
+		{input: "B2", expected: [2]int{1, 1}},
+		{input: "a1", expected: [2]int{0, 0}},
+		{input: "C1", expected: [2]int{0, 2}},
+		{input: "1 2", expected: [2]int{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		i, j, err := ParseMove(tc.input, 3)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tc.input, err)
+			continue
+		}
+
+		if i != tc.expected[0] || j != tc.expected[1] {
+			t.Errorf("parsed %q as (%d, %d) != expected %v", tc.input, i, j, tc.expected)
+		}
+	}
+
+	if _, _, err := ParseMove(" Resign\n", 3); err != ErrResigned {
+		t.Errorf("error %v != %v", err, ErrResigned)
+	}
+
+	if _, _, err := ParseMove("UNDO", 3); err != ErrUndo {
+		t.Errorf("error %v != %v", err, ErrUndo)
+	}
+
+	if _, _, err := ParseMove("é1", 3); err == nil || err.Error() != `column "é" is not a letter` {
+		t.Errorf("error %v should name the column é", err)
+	}
+
+	for _, input := range []string{"Z9", "D1", "A4", "A0", "3 0", "11", "B", "1 2 3", ""} {
+		if _, _, err := ParseMove(input, 3); err == nil {
+			t.Errorf("parsing %q should return an error", input)
+		}
+	}
+}
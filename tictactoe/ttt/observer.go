@@ -0,0 +1,27 @@
+package ttt
+
+// Observer is notified of the progress of a game. It can be used to drive a user interface or to
+// log a game without changing the game loop.
+type Observer interface {
+	// OnMove is called after player p places a mark on position (i, j).
+	OnMove(p Player, i, j int)
+	// OnGameOver is called once the game is over. The winner is nil if the game ends in a draw.
+	OnGameOver(winner Player)
+}
+
+// AddObserver registers an observer to be notified of the progress of the game.
+func (g *Game) AddObserver(o Observer) {
+	g.observers = append(g.observers, o)
+}
+
+func (g *Game) notifyMove(p Player, i, j int) {
+	for _, o := range g.observers {
+		o.OnMove(p, i, j)
+	}
+}
+
+func (g *Game) notifyGameOver(winner Player) {
+	for _, o := range g.observers {
+		o.OnGameOver(winner)
+	}
+}
@@ -0,0 +1,57 @@
+package ttt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// recordingObserver records every callback it receives.
+type recordingObserver struct {
+	events []string
+}
+
+func (ro *recordingObserver) OnMove(p Player, i, j int) {
+	ro.events = append(ro.events, fmt.Sprintf("move %s %d %d", p.Name(), i, j))
+}
+
+func (ro *recordingObserver) OnGameOver(winner Player) {
+	if winner == nil {
+		ro.events = append(ro.events, "draw")
+		return
+	}
+
+	ro.events = append(ro.events, "winner "+winner.Name())
+}
+
+func TestObserver(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {0, 0}, {1, 1}, {2, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {0, 2}}}
+
+	ro := &recordingObserver{}
+	g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}))
+	g.AddObserver(ro)
+	if _, err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The illegal move of Alice is not reported.
+	expected := []string{
+		"move Alice 0 0",
+		"move Bob 0 1",
+		"move Alice 1 1",
+		"move Bob 0 2",
+		"move Alice 2 2",
+		"winner Alice",
+	}
+
+	if len(ro.events) != len(expected) {
+		t.Fatalf("events %v != %v", ro.events, expected)
+	}
+
+	for k := range expected {
+		if ro.events[k] != expected[k] {
+			t.Errorf("events %v != %v", ro.events, expected)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package ttt
+
+// opening is an entry of the opening book. The opponent has played the positions of played on an
+// empty 3 by 3 board, and reply is the move to make in response.
+type opening struct {
+	played []Position
+	reply  Position
+}
+
+// openingBook covers the first two moves on a 3 by 3 board. Open in the center, answer a corner
+// or an edge with the center, and answer the center with a corner. Positions that are symmetric to
+// an entry are covered as well.
+var openingBook = []opening{
+	{played: nil, reply: Position{Row: 1, Col: 1}},
+	{played: []Position{{0, 0}}, reply: Position{Row: 1, Col: 1}},
+	{played: []Position{{0, 1}}, reply: Position{Row: 1, Col: 1}},
+	{played: []Position{{1, 1}}, reply: Position{Row: 0, Col: 0}},
+}
+
+// openingMove looks up the move of mark in the opening book by the canonical form of the board. It
+// returns false if the board is not a standard 3 by 3 board of two players or the position is not
+// in the book.
+func openingMove(b *Board, mark string) (Position, bool) {
+	if b.rows != 3 || b.cols != 3 || b.winLength != 3 || len(b.marks) != 2 {
+		return Position{}, false
+	}
+
+	form := b.CanonicalForm()
+	for _, entry := range openingBook {
+		book := NewBoard(3)
+		for _, pos := range entry.played {
+			book.grid[pos.Row][pos.Col] = b.Opponent(mark)
+		}
+
+		if book.CanonicalForm() != form {
+			continue
+		}
+
+		// The reply is translated onto the board by finding the move that leads to a position
+		// symmetric to the position of the book.
+		book.grid[entry.reply.Row][entry.reply.Col] = mark
+		target := book.CanonicalForm()
+		for _, pos := range b.GetAvailablePos() {
+			newBoard := b.Copy()
+			newBoard.PlaceMark(pos.Row, pos.Col, mark)
+			if newBoard.CanonicalForm() == target {
+				return pos, true
+			}
+		}
+	}
+
+	return Position{}, false
+}
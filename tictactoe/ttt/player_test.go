@@ -0,0 +1,69 @@
+package ttt
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// midGameBoard returns a position after a few moves where it is O's turn.
+func midGameBoard() *Board {
+	return boardOf(
+		[]string{"X", "_", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"_", "_", "X"},
+	)
+}
+
+func benchmarkGetMove(b *testing.B, board func() *Board, mark string) {
+	cp := NewComputerPlayer("HAL9000", mark, Hard)
+	cp.useBook = false
+	nodes := 0
+	for n := 0; n < b.N; n++ {
+		cp.GetMove(board())
+		nodes += cp.nodesVisited
+	}
+	b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+}
+
+func BenchmarkMinimaxEmptyBoard(b *testing.B) {
+	benchmarkGetMove(b, func() *Board { return NewBoard(3) }, "X")
+}
+
+func BenchmarkMinimaxMidGame(b *testing.B) {
+	benchmarkGetMove(b, midGameBoard, "O")
+}
+
+func benchmarkMemoization(b *testing.B, memoize bool) {
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	cp.memoize = memoize
+	cp.useBook = false
+	for n := 0; n < b.N; n++ {
+		cp.GetMove(NewBoard(3))
+	}
+	b.ReportMetric(float64(cp.nodesVisited), "nodes/op")
+}
+
+func BenchmarkMinimaxWithCache(b *testing.B) {
+	benchmarkMemoization(b, true)
+}
+
+func BenchmarkMinimaxWithoutCache(b *testing.B) {
+	benchmarkMemoization(b, false)
+}
+
+func BenchmarkMinimaxSequential(b *testing.B) {
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	for n := 0; n < b.N; n++ {
+		cp.minimax(newSearch(context.Background(), 0), NewBoard(3), cp.Mark(), 1, math.MinInt32,
+			math.MaxInt32)
+	}
+}
+
+func BenchmarkMinimaxParallel(b *testing.B) {
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	cp.useBook = false
+	for n := 0; n < b.N; n++ {
+		cp.GetMove(NewBoard(3))
+	}
+}
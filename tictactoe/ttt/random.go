@@ -0,0 +1,81 @@
+package ttt
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// NewRandomPlayer is a constructor for random player. The source of randomness is injected so that
+// its moves can be reproduced, it defaults to a shared source if r is nil.
+func NewRandomPlayer(n, m string, r *rand.Rand) *RandomPlayer {
+	return &RandomPlayer{
+		name:   n,
+		mark:   m,
+		random: r,
+	}
+}
+
+// RandomPlayer is an automated player that picks any available position.
+type RandomPlayer struct {
+	name   string
+	mark   string
+	random *rand.Rand
+}
+
+// GetMove returns next move.
+func (rp *RandomPlayer) GetMove(b *Board) (int, int, error) {
+	return randomMove(b, rp.rng())
+}
+
+func (rp *RandomPlayer) rng() *rand.Rand {
+	if rp.random == nil {
+		return defaultRandom
+	}
+
+	return rp.random
+}
+
+// Mark is a getter for player's mark.
+func (rp *RandomPlayer) Mark() string {
+	return rp.mark
+}
+
+// Name is a getter for player's name.
+func (rp *RandomPlayer) Name() string {
+	return rp.name
+}
+
+// randomMove picks one of the available positions uniformly at random.
+func randomMove(b *Board, r *rand.Rand) (int, int, error) {
+	availPos := b.GetAvailablePos()
+	if len(availPos) == 0 {
+		return 0, 0, errors.New("there is no available position")
+	}
+
+	pos := availPos[r.Intn(len(availPos))]
+	return pos.Row, pos.Col, nil
+}
+
+// defaultRandom is the source of randomness of players that are not given one. It is safe for
+// concurrent use.
+var defaultRandom = rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())})
+
+// lockedSource guards a source of randomness with a mutex.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
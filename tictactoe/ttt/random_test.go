@@ -0,0 +1,50 @@
+package ttt
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomPlayer(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "O", "X"},
+		[]string{"_", "O", "_"},
+		[]string{"X", "_", "O"},
+	)
+
+	rp := NewRandomPlayer("R2D2", "X", rand.New(rand.NewSource(42)))
+	for n := 0; n < 10; n++ {
+		i, j, err := rp.GetMove(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !b.IsLegalMove(i, j) {
+			t.Errorf("move (%d, %d) is not an available position", i, j)
+		}
+	}
+
+	if rp.Name() != "R2D2" || rp.Mark() != "X" {
+		t.Errorf("name %q and mark %q != R2D2 and X", rp.Name(), rp.Mark())
+	}
+
+	if _, _, err := rp.GetMove(boardOf(
+		[]string{"X", "O", "X"},
+		[]string{"X", "O", "O"},
+		[]string{"O", "X", "X"},
+	)); err == nil {
+		t.Error("expected error when there is no available position")
+	}
+}
+
+func TestRandomPlayerDefaultSource(t *testing.T) {
+	b := midGameBoard()
+	i, j, err := NewRandomPlayer("R2D2", "O", nil).GetMove(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !b.IsLegalMove(i, j) {
+		t.Errorf("move (%d, %d) is not an available position", i, j)
+	}
+}
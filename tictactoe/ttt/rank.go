@@ -0,0 +1,118 @@
+package ttt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// RankedMove is a legal move along with its minimax score. A positive score means the player can
+// force a win, a negative score means the opponent can, and zero means a draw with best play.
+// Quicker wins and slower losses score further from zero.
+type RankedMove struct {
+	I     int
+	J     int
+	Score int
+}
+
+// RankMoves returns every legal move with its score, best first. Moves with the same score keep
+// the order in which the search considers them, central positions first. Symmetric moves share a
+// score and are only searched once. The score is limited by the maximum depth of the player, if it
+// is set. It returns an empty slice if the game is over.
+func (cp *ComputerPlayer) RankMoves(b *Board) []RankedMove {
+	ranked, _ := cp.rankMoves(context.Background(), b)
+	return ranked
+}
+
+// rankMoves ranks the moves like RankMoves, and it stops searching once ctx is cancelled. When the
+// search is cancelled it returns the error of ctx, and the moves whose search did not complete are
+// ranked with a score of zero.
+func (cp *ComputerPlayer) rankMoves(ctx context.Context, b *Board) ([]RankedMove, error) {
+	cp.nodesVisited = 0
+	if b.IsOver() {
+		return []RankedMove{}, nil
+	}
+
+	positions := b.GetAvailablePosOrdered()
+	distinct := distinctMoves(b, cp.Mark(), positions)
+	values, _, nodes, err := cp.scoreMoves(ctx, b, distinct, cp.maxDepth)
+	cp.nodesVisited = nodes
+
+	scores := make(map[string]int)
+	for k, pos := range distinct {
+		scores[childForm(b, pos, cp.Mark())] = values[k]
+	}
+
+	ranked := make([]RankedMove, len(positions))
+	for k, pos := range positions {
+		ranked[k] = RankedMove{I: pos.Row, J: pos.Col, Score: scores[childForm(b, pos, cp.Mark())]}
+	}
+
+	sort.SliceStable(ranked, func(x, y int) bool {
+		return ranked[x].Score > ranked[y].Score
+	})
+
+	return ranked, err
+}
+
+// PrintRankings prints a table of every legal move of p on the board with its score, best first,
+// see RankMoves. Moves that score as well as the best move are flagged with an asterisk, p picks
+// one of them. It prints a note instead of a table if the game is over.
+func PrintRankings(b *Board, p *ComputerPlayer, out io.Writer) {
+	ranked := p.RankMoves(b)
+	if len(ranked) == 0 {
+		fmt.Fprintln(out, "There are no moves to rank, the game is over.")
+		return
+	}
+
+	moves := make([]string, len(ranked))
+	width := len("Move")
+	for k, m := range ranked {
+		moves[k] = fmt.Sprintf("(%d, %d)", m.I, m.J)
+		if len(moves[k]) > width {
+			width = len(moves[k])
+		}
+	}
+
+	fmt.Fprintf(out, "  %-*s  %5s\n", width, "Move", "Score")
+	for k, m := range ranked {
+		flag := " "
+		if m.Score == ranked[0].Score {
+			flag = "*"
+		}
+
+		fmt.Fprintf(out, "%s %-*s  %5d\n", flag, width, moves[k], m.Score)
+	}
+}
+
+// childForm returns the canonical form of the board after mark is placed on pos.
+func childForm(b *Board, pos Position, mark string) string {
+	newBoard := b.Copy()
+	newBoard.PlaceMark(pos.Row, pos.Col, mark)
+	return newBoard.CanonicalForm()
+}
+
+// sampleMove picks one of the ranked moves at random with softmax weights, i.e. a probability
+// proportional to exp(score / temperature). The ranked moves must not be empty.
+func sampleMove(ranked []RankedMove, temperature float64, r *rand.Rand) RankedMove {
+	// Scores are shifted by the best score so that the weights cannot overflow.
+	weights := make([]float64, len(ranked))
+	total := 0.0
+	for k, m := range ranked {
+		weights[k] = math.Exp(float64(m.Score-ranked[0].Score) / temperature)
+		total += weights[k]
+	}
+
+	x := r.Float64() * total
+	for k, w := range weights {
+		if x < w {
+			return ranked[k]
+		}
+		x -= w
+	}
+
+	return ranked[len(ranked)-1]
+}
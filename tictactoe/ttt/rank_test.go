@@ -0,0 +1,139 @@
+package ttt
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRankMoves(t *testing.T) {
+	b := boardOf(
+		[]string{"O", "O", "_"},
+		[]string{"X", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	ranked := cp.RankMoves(b)
+	if len(ranked) != len(b.GetAvailablePos()) {
+		t.Fatalf("ranked moves %v should include every legal move", ranked)
+	}
+
+	if best := ranked[0]; best.I != 0 || best.J != 2 || best.Score <= 0 {
+		t.Errorf("best move %+v != winning move (0, 2) with a positive score", best)
+	}
+
+	for k := 1; k < len(ranked); k++ {
+		if ranked[k].Score >= ranked[0].Score {
+			t.Errorf("move %+v should score lower than the winning move %+v", ranked[k], ranked[0])
+		}
+
+		if ranked[k].Score > ranked[k-1].Score {
+			t.Errorf("ranked moves %v are not sorted best first", ranked)
+		}
+	}
+}
+
+func TestPrintRankings(t *testing.T) {
+	b := boardOf(
+		[]string{"O", "O", "_"},
+		[]string{"X", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	out := &bytes.Buffer{}
+	PrintRankings(b, NewComputerPlayer("HAL9000", "O", Hard), out)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 1+len(b.GetAvailablePos()) {
+		t.Fatalf("table should have a header and a row per legal move\n%s", out)
+	}
+
+	if lines[0] != "  Move    Score" {
+		t.Errorf("header %q != %q", lines[0], "  Move    Score")
+	}
+
+	if expected := "* (0, 2)      8"; lines[1] != expected {
+		t.Errorf("best row %q != %q", lines[1], expected)
+	}
+
+	previous := 0
+	for k, line := range lines[1:] {
+		if k > 0 && strings.HasPrefix(line, "*") {
+			t.Errorf("row %q should not be flagged", line)
+		}
+
+		score, err := strconv.Atoi(strings.TrimSpace(line[len(line)-5:]))
+		if err != nil {
+			t.Fatalf("row %q has no score: %v", line, err)
+		}
+
+		if k > 0 && score > previous {
+			t.Errorf("scores are not sorted best first\n%s", out)
+		}
+		previous = score
+	}
+
+	out.Reset()
+	PrintRankings(boardOf(
+		[]string{"X", "O", "X"},
+		[]string{"X", "O", "O"},
+		[]string{"O", "X", "X"},
+	), NewComputerPlayer("HAL9000", "O", Hard), out)
+	if !strings.Contains(out.String(), "game is over") {
+		t.Errorf("output should note that the game is over\n%s", out)
+	}
+}
+
+func TestRankMovesSymmetric(t *testing.T) {
+	cp := NewComputerPlayer("HAL9000", "X", Hard)
+	ranked := cp.RankMoves(NewBoard(3))
+	if len(ranked) != 9 {
+		t.Fatalf("ranked moves %v should include every position", ranked)
+	}
+
+	for _, m := range ranked {
+		if m.Score != 0 {
+			t.Errorf("move %+v != a draw with best play", m)
+		}
+	}
+}
+
+func TestComputerPlayerTemperature(t *testing.T) {
+	b := boardOf(
+		[]string{"O", "O", "_"},
+		[]string{"X", "X", "_"},
+		[]string{"_", "_", "X"},
+	)
+
+	cp := NewComputerPlayer("HAL9000", "O", Hard)
+	cp.SetRandom(rand.New(rand.NewSource(1)))
+	for n := 0; n < 10; n++ {
+		if i, j, _ := cp.GetMove(b); i != 0 || j != 2 {
+			t.Fatalf("move (%d, %d) != top ranked move (0, 2) at temperature 0", i, j)
+		}
+	}
+
+	cp.SetTemperature(100)
+	weaker := false
+	for n := 0; n < 20; n++ {
+		i, j, err := cp.GetMove(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !b.IsLegalMove(i, j) {
+			t.Fatalf("move (%d, %d) is not legal", i, j)
+		}
+
+		if i != 0 || j != 2 {
+			weaker = true
+		}
+	}
+
+	if !weaker {
+		t.Error("a high temperature should pick a lower ranked move now and then")
+	}
+}
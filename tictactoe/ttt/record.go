@@ -0,0 +1,143 @@
+package ttt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxRecordSize is the largest board a game record can describe, since columns are written as the
+// letters a to z.
+const maxRecordSize = 26
+
+// ExportGame writes the moves as a numbered list with one round of two moves per line, each move
+// being a mark and a position in algebraic notation, e.g. "1. X b2 O a1". The list is followed by
+// a result line, "Result: " and the mark of the winner, "draw", or "*" if the game is not over. The
+// result is decided on the smallest square board, at least 3 by 3, that holds every move. It
+// returns an error if a move cannot be written, e.g. a mark contains a space, or it is illegal.
+func ExportGame(moves []Move, w io.Writer) error {
+	for _, m := range moves {
+		if m.I < 0 || m.J < 0 || m.I >= maxRecordSize || m.J >= maxRecordSize {
+			return fmt.Errorf("position (%d, %d) cannot be written", m.I, m.J)
+		}
+
+		if m.Mark == "" || strings.ContainsAny(m.Mark, " \t\n") || isRoundNumber(m.Mark) {
+			return fmt.Errorf("mark %q cannot be written", m.Mark)
+		}
+	}
+
+	b := boardForMoves(moves)
+	for _, m := range moves {
+		if err := b.PlaceMark(m.I, m.J, m.Mark); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for k := 0; k < len(moves); k += 2 {
+		fmt.Fprintf(bw, "%d. %s %s", k/2+1, moves[k].Mark, formatPos(moves[k].I, moves[k].J))
+		if k+1 < len(moves) {
+			fmt.Fprintf(bw, " %s %s", moves[k+1].Mark, formatPos(moves[k+1].I, moves[k+1].J))
+		}
+		fmt.Fprintln(bw)
+	}
+
+	result := "*"
+	switch {
+	case b.Winner() != "":
+		result = b.Winner()
+	case b.IsDraw():
+		result = "draw"
+	}
+	fmt.Fprintln(bw, "Result:", result)
+
+	return bw.Flush()
+}
+
+// ImportGame reads a game record written by ExportGame and returns its moves. The result line is
+// optional. It returns an error carrying the line number if the record is malformed.
+func ImportGame(r io.Reader) ([]Move, error) {
+	moves := []Move{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "Result:" {
+			continue
+		}
+
+		if !isRoundNumber(fields[0]) || len(fields)%2 != 1 || len(fields) > 5 {
+			return nil, fmt.Errorf("line %d: expected a round number followed by one or two moves", n)
+		}
+
+		for k := 1; k < len(fields); k += 2 {
+			i, j, err := ParseMove(fields[k+1], maxRecordSize)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+
+			moves = append(moves, Move{I: i, J: j, Mark: fields[k]})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+// formatPos writes position (i, j) in algebraic notation, the reverse of ParseMove.
+func formatPos(i, j int) string {
+	return string(rune('a'+j)) + strconv.Itoa(i+1)
+}
+
+// isRoundNumber checks if s is a round number of a game record such as "12.".
+func isRoundNumber(s string) bool {
+	if !strings.HasSuffix(s, ".") {
+		return false
+	}
+
+	_, err := strconv.Atoi(strings.TrimSuffix(s, "."))
+	return err == nil
+}
+
+// boardForMoves returns the smallest empty square board, at least 3 by 3, that holds every move.
+// Its marks are the marks of the moves, the default ones fill in if there are fewer than two.
+func boardForMoves(moves []Move) *Board {
+	size := 3
+	for _, m := range moves {
+		if m.I >= size {
+			size = m.I + 1
+		}
+		if m.J >= size {
+			size = m.J + 1
+		}
+	}
+
+	marks := recordMarks(moves)
+	for _, mark := range []string{"X", "O"} {
+		if len(marks) < 2 && (len(marks) == 0 || marks[0] != mark) {
+			marks = append(marks, mark)
+		}
+	}
+
+	b := NewBoard(size)
+	b.SetMarks(marks...)
+	return b
+}
+
+// recordMarks returns the marks of the moves in the order they first appear.
+func recordMarks(moves []Move) []string {
+	seen := make(map[string]bool)
+	marks := []string{}
+	for _, m := range moves {
+		if !seen[m.Mark] {
+			seen[m.Mark] = true
+			marks = append(marks, m.Mark)
+		}
+	}
+
+	return marks
+}
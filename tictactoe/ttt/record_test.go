@@ -0,0 +1,88 @@
+package ttt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportGame(t *testing.T) {
+	moves := []Move{
+		{I: 1, J: 1, Mark: "X"},
+		{I: 0, J: 0, Mark: "O"},
+		{I: 0, J: 2, Mark: "X"},
+		{I: 2, J: 0, Mark: "O"},
+		{I: 1, J: 0, Mark: "X"},
+		{I: 1, J: 2, Mark: "O"},
+		{I: 2, J: 2, Mark: "X"},
+		{I: 0, J: 1, Mark: "O"},
+		{I: 2, J: 1, Mark: "X"},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := ExportGame(moves, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "1. X b2 O a1\n2. X c1 O a3\n3. X a2 O c2\n4. X c3 O b1\n5. X b3\nResult: draw\n"
+	if buf.String() != expected {
+		t.Errorf("record\n%s\n!= expected record\n%s", buf, expected)
+	}
+
+	imported, err := ImportGame(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(imported) != len(moves) {
+		t.Fatalf("imported moves %v != %v", imported, moves)
+	}
+
+	for k := range moves {
+		if imported[k] != moves[k] {
+			t.Errorf("imported moves %v != %v", imported, moves)
+			break
+		}
+	}
+}
+
+func TestExportGameResult(t *testing.T) {
+	testCases := []struct {
+		moves    []Move
+		expected string
+	}{
+		{moves: []Move{}, expected: "Result: *\n"},
+		{moves: []Move{{I: 0, J: 0, Mark: "A"}}, expected: "1. A a1\nResult: *\n"},
+		{
+			moves: []Move{
+				{I: 0, J: 0, Mark: "X"}, {I: 1, J: 0, Mark: "O"},
+				{I: 0, J: 1, Mark: "X"}, {I: 1, J: 1, Mark: "O"},
+				{I: 0, J: 2, Mark: "X"},
+			},
+			expected: "Result: X\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if err := ExportGame(tc.moves, buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.HasSuffix(buf.String(), tc.expected) {
+			t.Errorf("record %q should end with %q", buf, tc.expected)
+		}
+	}
+
+	if err := ExportGame([]Move{{I: 0, J: 0, Mark: "X X"}}, &bytes.Buffer{}); err == nil {
+		t.Error("exporting a mark with a space should return an error")
+	}
+}
+
+func TestImportGameMalformed(t *testing.T) {
+	for _, record := range []string{"1. X b2 O\n", "X b2\n", "1. X b2\n2. O b0\n"} {
+		if _, err := ImportGame(strings.NewReader(record)); err == nil {
+			t.Errorf("importing %q should return an error", record)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package ttt
+
+import (
+	"io"
+	"os"
+)
+
+// Renderer turns a board into the text that is printed to the players.
+type Renderer interface {
+	Render(b *Board) string
+}
+
+// ANSI escape codes used by ColorRenderer.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// defaultColors are given to the marks of a board in the order they take turns.
+var defaultColors = []string{ansiRed, ansiBlue, ansiGreen, ansiYellow, ansiMagenta, ansiCyan}
+
+// NewColorRenderer is a constructor for a renderer of boards printed to w. Colors are turned on
+// only if w is a terminal and the NO_COLOR environment variable is not set.
+func NewColorRenderer(w io.Writer) *ColorRenderer {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return &ColorRenderer{
+		colors:  make(map[string]string),
+		enabled: isTerminal(w) && !noColor,
+	}
+}
+
+// ColorRenderer renders a board like Board.String, with every mark in its own color.
+type ColorRenderer struct {
+	colors  map[string]string
+	enabled bool
+}
+
+// SetColor sets the ANSI escape code that mark is rendered with, e.g. "\x1b[31m" for red. Marks
+// without a color of their own get a default color by the order they take turns.
+func (r *ColorRenderer) SetColor(mark, code string) {
+	r.colors[mark] = code
+}
+
+// SetEnabled turns colors on or off regardless of the writer and the environment.
+func (r *ColorRenderer) SetEnabled(enabled bool) {
+	r.enabled = enabled
+}
+
+// Render implements the Renderer interface. It falls back to Board.String when colors are off.
+func (r *ColorRenderer) Render(b *Board) string {
+	if !r.enabled {
+		return b.String()
+	}
+
+	return b.format(func(mark string) string {
+		return r.color(b, mark) + mark + ansiReset
+	})
+}
+
+func (r *ColorRenderer) color(b *Board, mark string) string {
+	if code, ok := r.colors[mark]; ok {
+		return code
+	}
+
+	for k, m := range b.marks {
+		if m == mark {
+			return defaultColors[k%len(defaultColors)]
+		}
+	}
+
+	return ""
+}
+
+// isTerminal checks if w is a character device, such as a terminal, rather than a file or a pipe.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
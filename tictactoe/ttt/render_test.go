@@ -0,0 +1,34 @@
+package ttt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorRenderer(t *testing.T) {
+	b := boardOf(
+		[]string{"X", "_", "_"},
+		[]string{"_", "O", "_"},
+		[]string{"_", "_", "_"},
+	)
+
+	r := NewColorRenderer(&bytes.Buffer{})
+	if rendered := r.Render(b); rendered != b.String() {
+		t.Errorf("rendered board\n%s\n!= plain board\n%s", rendered, b)
+	}
+
+	r.SetEnabled(true)
+	r.SetColor("O", ansiGreen)
+	rendered := r.Render(b)
+	for _, expected := range []string{ansiRed + "X" + ansiReset, ansiGreen + "O" + ansiReset} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("rendered board %q should contain %q", rendered, expected)
+		}
+	}
+
+	plain := strings.NewReplacer(ansiRed, "", ansiGreen, "", ansiReset, "").Replace(rendered)
+	if plain != b.String() {
+		t.Errorf("rendered board without colors\n%s\n!= plain board\n%s", plain, b)
+	}
+}
@@ -0,0 +1,69 @@
+package ttt
+
+import "sync"
+
+// NewSafeGame wraps g so that it can be shared by goroutines, e.g. the handlers of a server. Once
+// wrapped, neither g nor its board may be used directly, every access has to go through the
+// wrapper.
+func NewSafeGame(g *Game) *SafeGame {
+	return &SafeGame{game: g}
+}
+
+// SafeGame is a game that is safe for concurrent use. Steps of the game are serialized, and reads
+// of its state never observe a step half way through.
+type SafeGame struct {
+	mu   sync.RWMutex
+	game *Game
+}
+
+// Step plays a single turn of the game, see Game.Step. The state of the game cannot be read while
+// the current player thinks about the move.
+func (sg *SafeGame) Step() (done bool, winner Player, err error) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.game.Step()
+}
+
+// Play plays the game until it is over, see Game.Play. Every turn is a separate step, so the state
+// of the game can be read between turns.
+func (sg *SafeGame) Play() (Player, error) {
+	for {
+		done, winner, err := sg.Step()
+		if err != nil {
+			return nil, err
+		}
+
+		if done {
+			return winner, nil
+		}
+	}
+}
+
+// WinnerPlayer returns the player who has won, or nil if the game is a draw or still going on, see
+// Game.WinnerPlayer.
+func (sg *SafeGame) WinnerPlayer() Player {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.game.WinnerPlayer()
+}
+
+// Board returns a copy of the board of the game, which the caller is free to modify.
+func (sg *SafeGame) Board() *Board {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.game.board.Copy()
+}
+
+// CurrentPlayer returns the player who moves next.
+func (sg *SafeGame) CurrentPlayer() Player {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.game.currentPlayer()
+}
+
+// Over checks if the game is over.
+func (sg *SafeGame) Over() bool {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.game.over
+}
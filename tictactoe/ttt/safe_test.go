@@ -0,0 +1,54 @@
+package ttt
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestSafeGame(t *testing.T) {
+	p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {1, 1}, {2, 2}}}
+	p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {0, 2}}}
+	sg := NewSafeGame(mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{})))
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// A board read between steps holds a mark for every move of its history.
+				b := sg.Board()
+				if n := b.rows*b.cols - b.EmptyCount(); n != len(b.History()) {
+					t.Errorf("board with %d marks and %d moves", n, len(b.History()))
+				}
+				sg.WinnerPlayer()
+				sg.CurrentPlayer()
+				sg.Over()
+			}
+		}()
+	}
+
+	winner, err := sg.Play()
+	close(stop)
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if winner != p1 || sg.WinnerPlayer() != p1 || !sg.Over() {
+		t.Errorf("winner %v != Alice once the game is over", winner)
+	}
+
+	if n := len(sg.Board().History()); n != 5 {
+		t.Errorf("actual %d moves != expected 5 moves", n)
+	}
+}
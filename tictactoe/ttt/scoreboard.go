@@ -0,0 +1,83 @@
+package ttt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result is the outcome of a game. Winner is the name of the player who won, or empty if the game
+// ended in a draw.
+type Result struct {
+	Players []string
+	Winner  string
+}
+
+// Tally is the number of games a player won, lost and drew.
+type Tally struct {
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// NewScoreboard is a constructor for an empty scoreboard.
+func NewScoreboard() *Scoreboard {
+	return &Scoreboard{
+		tallies: make(map[string]*Tally),
+	}
+}
+
+// Scoreboard keeps a running tally of the results of every player by name.
+type Scoreboard struct {
+	tallies map[string]*Tally
+
+	// names are the players in the order they were first recorded.
+	names []string
+}
+
+// Record adds the result of a game. The winner is credited with a win and every other player with
+// a loss, and every player is credited with a draw if there is no winner.
+func (s *Scoreboard) Record(result Result) {
+	for _, name := range result.Players {
+		t := s.tally(name)
+		switch result.Winner {
+		case "":
+			t.Draws++
+		case name:
+			t.Wins++
+		default:
+			t.Losses++
+		}
+	}
+}
+
+// Tally returns the tally of the player with the given name.
+func (s *Scoreboard) Tally(name string) Tally {
+	if t, ok := s.tallies[name]; ok {
+		return *t
+	}
+
+	return Tally{}
+}
+
+// Summary returns one line per player with their wins, losses and draws, in the order the players
+// were first recorded.
+func (s *Scoreboard) Summary() string {
+	lines := make([]string, len(s.names))
+	for k, name := range s.names {
+		t := s.tallies[name]
+		lines[k] = fmt.Sprintf("%s: %d wins, %d losses, %d draws", name, t.Wins, t.Losses, t.Draws)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *Scoreboard) tally(name string) *Tally {
+	t, ok := s.tallies[name]
+	if !ok {
+		t = &Tally{}
+		s.tallies[name] = t
+		s.names = append(s.names, name)
+	}
+
+	return t
+}
@@ -0,0 +1,46 @@
+package ttt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScoreboard(t *testing.T) {
+	s := NewScoreboard()
+	s.Record(Result{Players: []string{"X", "O"}, Winner: "X"})
+	s.Record(Result{Players: []string{"O", "X"}, Winner: "X"})
+	s.Record(Result{Players: []string{"X", "O"}})
+
+	if tally := s.Tally("X"); tally != (Tally{Wins: 2, Draws: 1}) {
+		t.Errorf("tally of X %+v != 2 wins and 1 draw", tally)
+	}
+
+	if tally := s.Tally("O"); tally != (Tally{Losses: 2, Draws: 1}) {
+		t.Errorf("tally of O %+v != 2 losses and 1 draw", tally)
+	}
+
+	expected := "X: 2 wins, 0 losses, 1 draws\nO: 0 wins, 2 losses, 1 draws"
+	if summary := s.Summary(); summary != expected {
+		t.Errorf("summary\n%s\n!= expected summary\n%s", summary, expected)
+	}
+}
+
+func TestGameRecordsResult(t *testing.T) {
+	s := NewScoreboard()
+	for round := 0; round < 2; round++ {
+		p1 := &scriptedPlayer{name: "Alice", mark: "X", moves: [][2]int{{0, 0}, {1, 1}, {2, 2}}}
+		p2 := &scriptedPlayer{name: "Bob", mark: "O", moves: [][2]int{{0, 1}, {0, 2}}}
+		g := mustNewGame(t, p1, p2, WithOutput(&bytes.Buffer{}), WithScoreboard(s))
+		if _, err := g.Play(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if tally := s.Tally("Alice"); tally.Wins != 2 {
+		t.Errorf("wins of Alice %d != 2", tally.Wins)
+	}
+
+	if tally := s.Tally("Bob"); tally.Losses != 2 {
+		t.Errorf("losses of Bob %d != 2", tally.Losses)
+	}
+}
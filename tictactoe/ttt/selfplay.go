@@ -0,0 +1,29 @@
+package ttt
+
+import "io"
+
+// PlaySelfPlay plays a game between two automated players on an empty 3 by 3 board, first moving
+// first, and returns the result along with the moves in the order they were played. Nothing is
+// printed. On top of the result and the moves, it returns an error if the players cannot play each
+// other or a player fails to move. Without it a game that stopped early would give an empty result,
+// which reads as a draw, so a broken player could pass for a perfect one. The moves played before
+// the error are still returned.
+func PlaySelfPlay(first, second Player) (result Result, moves []Move, err error) {
+	g, err := NewGame(first, second, WithOutput(io.Discard))
+	if err != nil {
+		return Result{}, nil, err
+	}
+
+	winner, err := g.Play()
+	moves = g.board.History()
+	if err != nil {
+		return Result{}, moves, err
+	}
+
+	result.Players = []string{first.Name(), second.Name()}
+	if winner != nil {
+		result.Winner = winner.Name()
+	}
+
+	return result, moves, nil
+}
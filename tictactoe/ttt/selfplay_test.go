@@ -0,0 +1,40 @@
+package ttt
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPlaySelfPlay(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		first := NewComputerPlayer("HAL9000", "X", Hard)
+		first.SetRandom(rand.New(rand.NewSource(seed)))
+		second := NewComputerPlayer("Deep Thought", "O", Hard)
+		second.SetRandom(rand.New(rand.NewSource(seed)))
+
+		result, moves, err := PlaySelfPlay(first, second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Winner != "" {
+			t.Errorf("seed %d: winner %q should be empty, perfect players draw", seed, result.Winner)
+		}
+
+		// The game stops once no line can be completed, which takes at least 8 moves on a 3 by 3
+		// board when both players block every line.
+		if len(moves) < 8 || len(moves) > 9 {
+			t.Errorf("seed %d: %d moves should be 8 or 9 moves", seed, len(moves))
+		}
+
+		b, err := ReplayMoves(3, moves)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if winners := b.PossibleWinners(); len(winners) != 0 {
+			t.Errorf("seed %d: game should end in a dead draw, %v could still win\n%s", seed,
+				winners, b)
+		}
+	}
+}
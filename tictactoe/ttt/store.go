@@ -0,0 +1,60 @@
+package ttt
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrGameNotFound is returned by a game store that has no game with the requested id.
+var ErrGameNotFound = errors.New("game not found")
+
+// GameStore persists the moves of games by id, so that games can be kept in memory, in files or in
+// a database without the game logic knowing the details.
+type GameStore interface {
+	// Save stores the moves of the game with the given id, replacing the moves saved before.
+	Save(id string, moves []Move) error
+
+	// Load returns the moves of the game with the given id. It returns an error wrapping
+	// ErrGameNotFound if there is no such game.
+	Load(id string) ([]Move, error)
+}
+
+// NewMemoryStore is a constructor for an empty in memory game store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		games: make(map[string][]Move),
+	}
+}
+
+// MemoryStore is a game store that keeps the moves in memory. It is safe for concurrent use.
+type MemoryStore struct {
+	mu    sync.Mutex
+	games map[string][]Move
+}
+
+// Save stores a copy of the moves of the game with the given id.
+func (s *MemoryStore) Save(id string, moves []Move) error {
+	saved := make([]Move, len(moves))
+	copy(saved, moves)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games[id] = saved
+	return nil
+}
+
+// Load returns a copy of the moves of the game with the given id.
+func (s *MemoryStore) Load(id string) ([]Move, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved, ok := s.games[id]
+	if !ok {
+		return nil, fmt.Errorf("game %q: %w", id, ErrGameNotFound)
+	}
+
+	moves := make([]Move, len(saved))
+	copy(moves, saved)
+	return moves, nil
+}
@@ -0,0 +1,44 @@
+package ttt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	var s GameStore = NewMemoryStore()
+
+	moves := []Move{
+		{I: 1, J: 1, Mark: "X"},
+		{I: 0, J: 0, Mark: "O"},
+	}
+	if err := s.Save("1", moves); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Changing the saved slice does not change the store.
+	moves[0].Mark = "O"
+
+	loaded, err := s.Load("1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Move{
+		{I: 1, J: 1, Mark: "X"},
+		{I: 0, J: 0, Mark: "O"},
+	}
+	if len(loaded) != len(expected) {
+		t.Fatalf("loaded moves %v != %v", loaded, expected)
+	}
+
+	for k := range expected {
+		if loaded[k] != expected[k] {
+			t.Errorf("loaded moves %v != %v", loaded, expected)
+		}
+	}
+
+	if _, err := s.Load("2"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("error %v is not ErrGameNotFound", err)
+	}
+}
@@ -0,0 +1,42 @@
+package ttt
+
+import "fmt"
+
+// WithTeachMode warns a human player whose move throws away a position that was not lost, i.e.
+// after which the opponents can force a win although a draw or a win could still be held. The
+// warning names a move that would have held the position. Every move of a human player is checked
+// with a full search of the game tree, so teach mode is meant for small boards such as 3 by 3.
+func WithTeachMode() GameOption {
+	return func(g *Game) {
+		g.teach = true
+	}
+}
+
+// warnBlunder prints a warning if placing the mark of p on position (i, j) turns a position that
+// is not lost into a lost one. It is called before the move is placed and does nothing unless the
+// game is in teach mode, p is a human player and the move is legal.
+func (g *Game) warnBlunder(p Player, i, j int) {
+	if _, ok := p.(*HumanPlayer); !ok || !g.teach || !g.board.IsLegalMove(i, j) {
+		return
+	}
+
+	ranked := NewComputerPlayer(p.Name(), p.Mark(), Hard).RankMoves(g.board)
+	if len(ranked) == 0 || ranked[0].Score < 0 {
+		return
+	}
+
+	for _, m := range ranked {
+		if m.I != i || m.J != j || m.Score >= 0 {
+			continue
+		}
+
+		held := "the draw"
+		if ranked[0].Score > 0 {
+			held = "the win"
+		}
+
+		fmt.Fprintf(g.out, "That move loses — (%d, %d) would have held %s.\n", ranked[0].I,
+			ranked[0].J, held)
+		return
+	}
+}
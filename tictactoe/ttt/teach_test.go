@@ -0,0 +1,64 @@
+package ttt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTeachMode(t *testing.T) {
+	// O has to block the top row, every other move loses.
+	board := func() *Board {
+		b := boardOf(
+			[]string{"X", "X", "_"},
+			[]string{"_", "O", "_"},
+			[]string{"_", "_", "_"},
+		)
+		b.SetStartingMark("O")
+		return b
+	}
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Blunder", input: "2 2\n",
+			expected: "That move loses — (0, 2) would have held the draw."},
+		{name: "Block", input: "0 2\n", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			human := NewHumanPlayerWithIO("Calvin", "O", strings.NewReader(tc.input),
+				&bytes.Buffer{})
+			out := &bytes.Buffer{}
+			g := mustNewGame(t, &scriptedPlayer{name: "Alice", mark: "X"}, human,
+				WithBoard(board()), WithOutput(out), WithTeachMode())
+			if _, _, err := g.Step(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			warned := strings.Contains(out.String(), "That move loses")
+			if tc.expected == "" && warned {
+				t.Errorf("output should not warn about the move\n%s", out)
+			}
+
+			if tc.expected != "" && !strings.Contains(out.String(), tc.expected) {
+				t.Errorf("output should contain %q\n%s", tc.expected, out)
+			}
+		})
+	}
+
+	t.Run("Off", func(t *testing.T) {
+		human := NewHumanPlayerWithIO("Calvin", "O", strings.NewReader("2 2\n"), &bytes.Buffer{})
+		out := &bytes.Buffer{}
+		g := mustNewGame(t, &scriptedPlayer{name: "Alice", mark: "X"}, human, WithBoard(board()),
+			WithOutput(out))
+		g.Step()
+
+		if strings.Contains(out.String(), "That move loses") {
+			t.Errorf("output should not warn without teach mode\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package ttt
+
+import (
+	"fmt"
+	"io"
+)
+
+// TournamentResult is the outcome of a round robin tournament.
+type TournamentResult struct {
+	// Records holds the wins, losses and draws of every player by name.
+	Records map[string]Tally
+
+	// Games is the number of games that were played.
+	Games int
+
+	// Err is the error that stopped the tournament early, if any. Records holds the results of the
+	// games played before it.
+	Err error
+}
+
+// RunTournament plays a round robin tournament on 3 by 3 boards where every pair of players plays
+// rounds games, the player listed first going first in odd games and the other player in even
+// games. Every player needs a distinct mark. The tournament stops at the first game that fails.
+func RunTournament(players []Player, rounds int) TournamentResult {
+	s := NewScoreboard()
+	result := TournamentResult{Records: make(map[string]Tally)}
+
+	for x := 0; x < len(players) && result.Err == nil; x++ {
+		for y := x + 1; y < len(players) && result.Err == nil; y++ {
+			for round := 0; round < rounds; round++ {
+				first, second := players[x], players[y]
+				if round%2 == 1 {
+					first, second = second, first
+				}
+
+				g, err := NewGame(first, second, WithOutput(io.Discard), WithScoreboard(s))
+				if err == nil {
+					_, err = g.Play()
+				}
+
+				if err != nil {
+					result.Err = fmt.Errorf("%s against %s: %w", first.Name(), second.Name(), err)
+					break
+				}
+				result.Games++
+			}
+		}
+	}
+
+	for _, p := range players {
+		result.Records[p.Name()] = s.Tally(p.Name())
+	}
+
+	return result
+}
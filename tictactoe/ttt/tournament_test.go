@@ -0,0 +1,58 @@
+package ttt
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRunTournament(t *testing.T) {
+	players := []Player{
+		NewComputerPlayer("Hard", "X", Hard),
+		NewHeuristicPlayer("Heuristic", "O", rand.New(rand.NewSource(42))),
+		NewRandomPlayer("Random", "Z", rand.New(rand.NewSource(42))),
+	}
+
+	rounds := 4
+	result := RunTournament(players, rounds)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+
+	if expected := rounds * 3; result.Games != expected {
+		t.Errorf("actual %d games != expected %d games", result.Games, expected)
+	}
+
+	wins, losses, draws := 0, 0, 0
+	for _, p := range players {
+		tally := result.Records[p.Name()]
+		if games := tally.Wins + tally.Losses + tally.Draws; games != rounds*(len(players)-1) {
+			t.Errorf("%s played %d games != expected %d games", p.Name(), games,
+				rounds*(len(players)-1))
+		}
+
+		wins, losses, draws = wins+tally.Wins, losses+tally.Losses, draws+tally.Draws
+	}
+
+	if wins != losses {
+		t.Errorf("actual %d wins != %d losses", wins, losses)
+	}
+
+	if wins+draws/2 != result.Games {
+		t.Errorf("actual %d decided and %d drawn games != %d games", wins, draws/2, result.Games)
+	}
+
+	if tally := result.Records["Hard"]; tally.Losses != 0 {
+		t.Errorf("Hard should never lose, %+v", tally)
+	}
+}
+
+func TestRunTournamentSharedMark(t *testing.T) {
+	players := []Player{
+		NewComputerPlayer("Hard", "X", Hard),
+		NewComputerPlayer("Easy", "X", Easy),
+	}
+
+	if result := RunTournament(players, 2); result.Err == nil {
+		t.Error("expected error when players share a mark")
+	}
+}